@@ -0,0 +1,127 @@
+// Package handlers for the various types of events
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is a domain of ErrorInfo details of errors returned to clients
+const errorDomain = "trading-service"
+
+// retryDelay is a delay after which clients are advised to retry requests failed by a temporary condition
+const retryDelay = time.Second
+
+// domainError struct represents a domain error of the service with gRPC code and reason it is returned to clients with
+type domainError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// domainErrors lists domain errors of the service which are returned to clients with their own code and reason
+// Validation errors and rejections by risk limits carry their violations and are converted separately
+var domainErrors = []domainError{
+	{err: model.ErrIdempotencyKeyReused, code: codes.InvalidArgument, reason: "IDEMPOTENCY_KEY_REUSED"},
+	{err: model.ErrPositionNotFound, code: codes.NotFound, reason: "POSITION_NOT_FOUND"},
+	{err: model.ErrOrderNotFound, code: codes.NotFound, reason: "ORDER_NOT_FOUND"},
+	{err: model.ErrInstrumentNotFound, code: codes.NotFound, reason: "INSTRUMENT_NOT_FOUND"},
+	{err: model.ErrInstrumentNotTradable, code: codes.FailedPrecondition, reason: "INSTRUMENT_NOT_TRADABLE"},
+	{err: model.ErrInsufficientFunds, code: codes.FailedPrecondition, reason: "INSUFFICIENT_FUNDS"},
+	{err: model.ErrPositionNotOpen, code: codes.FailedPrecondition, reason: "POSITION_NOT_OPEN"},
+	{err: model.ErrOrderNotActive, code: codes.FailedPrecondition, reason: "ORDER_NOT_ACTIVE"},
+	{err: model.ErrPositionConflict, code: codes.Aborted, reason: "POSITION_CONFLICT"},
+	{err: model.ErrOrderConflict, code: codes.Aborted, reason: "ORDER_CONFLICT"},
+	{err: model.ErrIdempotencyKeyInProgress, code: codes.Aborted, reason: "IDEMPOTENCY_KEY_IN_PROGRESS"},
+	{err: model.ErrPriceUnavailable, code: codes.Unavailable, reason: "PRICE_UNAVAILABLE"},
+}
+
+// errorStatus function converts an error of the service into gRPC status with a code and details clients can branch on
+// Every domain error has ErrorInfo with its reason, invalid fields are listed in BadRequest, violated conditions in PreconditionFailure
+// and temporary failures have RetryInfo. Errors out of domain taxonomy become Internal status without internal details
+func errorStatus(err error) error {
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		return newStatus(codes.InvalidArgument, validationErr.Error(), errorInfo("INVALID_ARGUMENT", nil), badRequest)
+	}
+	var riskErr *model.RiskLimitError
+	if errors.As(err, &riskErr) {
+		return riskLimitStatus(riskErr)
+	}
+	for _, domainErr := range domainErrors {
+		if !errors.Is(err, domainErr.err) {
+			continue
+		}
+		details := []protoiface.MessageV1{errorInfo(domainErr.reason, nil)}
+		switch domainErr.code {
+		case codes.FailedPrecondition:
+			details = append(details, &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        domainErr.reason,
+				Description: domainErr.err.Error(),
+			}}})
+		case codes.InvalidArgument:
+			details = append(details, &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       "idempotencyKey",
+				Description: domainErr.err.Error(),
+			}}})
+		case codes.Aborted, codes.Unavailable:
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+		}
+		return newStatus(domainErr.code, domainErr.err.Error(), details...)
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+// riskLimitStatus function converts rejection by risk limits into FailedPrecondition status
+// with violated limits in PreconditionFailure details and their values in ErrorInfo metadata
+func riskLimitStatus(riskErr *model.RiskLimitError) error {
+	failure := &errdetails.PreconditionFailure{}
+	metadata := make(map[string]string)
+	for _, violation := range riskErr.Violations {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        string(violation.Rule),
+			Subject:     violation.Subject,
+			Description: violation.Description(),
+		})
+		metadata[string(violation.Rule)+".limit"] = violation.Limit.String()
+		metadata[string(violation.Rule)+".value"] = violation.Value.String()
+	}
+	return newStatus(codes.FailedPrecondition, riskErr.Error(), errorInfo("RISK_LIMIT_EXCEEDED", metadata), failure)
+}
+
+// errorInfo function creates ErrorInfo details of the service with given reason
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata}
+}
+
+// newStatus function creates gRPC status error with details, status is returned without details if they can't be attached
+func newStatus(code codes.Code, message string, details ...protoiface.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(details...)
+	if err != nil {
+		logrus.Errorf("WithDetails: %v", err)
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
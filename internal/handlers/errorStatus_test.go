@@ -0,0 +1,134 @@
+// Package handlers for the various types of events
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorStatus(t *testing.T) {
+	riskErr := &model.RiskLimitError{Violations: []model.RiskViolation{{
+		Rule: model.RiskRuleMaxOrderSize, Subject: "AAPL", Limit: decimal.NewFromInt(1000), Value: decimal.NewFromInt(1500),
+	}}}
+	tests := []struct {
+		name      string
+		err       error
+		code      codes.Code
+		reason    string
+		retryable bool
+	}{
+		{
+			name:   "validation error",
+			err:    fmt.Errorf("OpenPosition: %w", model.NewValidationError("total", "total must be positive")),
+			code:   codes.InvalidArgument,
+			reason: "INVALID_ARGUMENT",
+		},
+		{name: "risk limits", err: fmt.Errorf("checkRiskLimits: %w", riskErr), code: codes.FailedPrecondition, reason: "RISK_LIMIT_EXCEEDED"},
+		{name: "reused idempotency key", err: model.ErrIdempotencyKeyReused, code: codes.InvalidArgument, reason: "IDEMPOTENCY_KEY_REUSED"},
+		{name: "not found", err: fmt.Errorf("GetPositionByID: %w", model.ErrPositionNotFound), code: codes.NotFound, reason: "POSITION_NOT_FOUND"},
+		{name: "insufficient funds", err: fmt.Errorf("ReserveBalance: %w", model.ErrInsufficientFunds), code: codes.FailedPrecondition, reason: "INSUFFICIENT_FUNDS"},
+		{name: "conflict", err: fmt.Errorf("ClosePosition: %w", model.ErrPositionConflict), code: codes.Aborted, reason: "POSITION_CONFLICT", retryable: true},
+		{name: "price is unavailable", err: fmt.Errorf("getSharePrice: %w", model.ErrPriceUnavailable), code: codes.Unavailable, reason: "PRICE_UNAVAILABLE", retryable: true},
+		{name: "canceled", err: fmt.Errorf("CreatePosition: %w", context.Canceled), code: codes.Canceled},
+		{name: "deadline exceeded", err: fmt.Errorf("CreatePosition: %w", context.DeadlineExceeded), code: codes.DeadlineExceeded},
+		{name: "internal error", err: errors.New("dial tcp 10.0.0.5:5432: connection refused"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(errorStatus(tt.err))
+			if !ok {
+				t.Fatalf("errorStatus(%v) is not a gRPC status", tt.err)
+			}
+			if st.Code() != tt.code {
+				t.Errorf("code is %v, want %v", st.Code(), tt.code)
+			}
+			var (
+				errorInfo *errdetails.ErrorInfo
+				retryInfo *errdetails.RetryInfo
+			)
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					errorInfo = detail
+				case *errdetails.RetryInfo:
+					retryInfo = detail
+				}
+			}
+			switch {
+			case tt.reason == "" && errorInfo != nil:
+				t.Errorf("error info has reason %q, want no error info", errorInfo.Reason)
+			case tt.reason != "" && (errorInfo == nil || errorInfo.Reason != tt.reason || errorInfo.Domain != errorDomain):
+				t.Errorf("error info is %v, want reason %q in domain %q", errorInfo, tt.reason, errorDomain)
+			}
+			if (retryInfo != nil) != tt.retryable {
+				t.Errorf("retry info is %v, want retryable %v", retryInfo, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestErrorStatusOfValidationError(t *testing.T) {
+	err := &model.ValidationError{Violations: []model.FieldViolation{
+		{Field: "total", Description: "total must be positive"},
+		{Field: "shareName", Description: "share name is required"},
+	}}
+	st, _ := status.FromError(errorStatus(err))
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = detail
+		}
+	}
+	if badRequest == nil {
+		t.Fatal("status has no bad request details")
+	}
+	if len(badRequest.FieldViolations) != 2 || badRequest.FieldViolations[0].Field != "total" || badRequest.FieldViolations[1].Field != "shareName" {
+		t.Errorf("field violations are %v, want total and shareName", badRequest.FieldViolations)
+	}
+}
+
+func TestErrorStatusOfRiskLimitError(t *testing.T) {
+	err := &model.RiskLimitError{Violations: []model.RiskViolation{{
+		Rule: model.RiskRuleMaxOrderSize, Subject: "AAPL", Limit: decimal.NewFromInt(1000), Value: decimal.NewFromInt(1500),
+	}}}
+	st, _ := status.FromError(errorStatus(err))
+	var (
+		errorInfo *errdetails.ErrorInfo
+		failure   *errdetails.PreconditionFailure
+	)
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		case *errdetails.PreconditionFailure:
+			failure = detail
+		}
+	}
+	if failure == nil || len(failure.Violations) != 1 || failure.Violations[0].Type != string(model.RiskRuleMaxOrderSize) || failure.Violations[0].Subject != "AAPL" {
+		t.Errorf("precondition failure is %v, want violated %v of AAPL", failure, model.RiskRuleMaxOrderSize)
+	}
+	if errorInfo == nil {
+		t.Fatal("status has no error info")
+	}
+	limit := errorInfo.Metadata[string(model.RiskRuleMaxOrderSize)+".limit"]
+	value := errorInfo.Metadata[string(model.RiskRuleMaxOrderSize)+".value"]
+	if limit != "1000" || value != "1500" {
+		t.Errorf("metadata has limit %q and value %q, want 1000 and 1500", limit, value)
+	}
+}
+
+func TestErrorStatusHidesInternalErrors(t *testing.T) {
+	st, _ := status.FromError(errorStatus(errors.New("dial tcp 10.0.0.5:5432: connection refused")))
+	if st.Message() != "internal error" || len(st.Details()) != 0 {
+		t.Errorf("internal error is returned as %q with details %v", st.Message(), st.Details())
+	}
+}
@@ -4,14 +4,21 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/eugenshima/trading-service/internal/model"
 	proto "github.com/eugenshima/trading-service/proto"
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// idempotencyKeyHeader is a gRPC metadata key of idempotency key of OpenPosition and ClosePosition requests
+const idempotencyKeyHeader = "idempotency-key"
+
 // TradingHandler struct ....
 type TradingHandler struct {
 	srv TradingService
@@ -26,56 +33,106 @@ func NewTradingHandler(srv TradingService, vl *validator.Validate) *TradingHandl
 
 // TradingService interface represents the underlying TradingService
 type TradingService interface {
-	OpenPosition(context.Context, *model.Position) error
-	ClosePosition(context.Context, uuid.UUID) (float64, error)
+	OpenPosition(context.Context, *model.Position, string) error
+	ClosePosition(context.Context, uuid.UUID, model.ClosePart, string) (*model.ProfitAndLoss, error)
+	IncreasePosition(context.Context, uuid.UUID, decimal.Decimal) (*model.Position, error)
+	GetPosition(context.Context, uuid.UUID) (*model.PositionDetails, error)
+	ListPositions(context.Context, *model.PositionFilter) ([]*model.PositionDetails, uuid.UUID, error)
+	StreamPositions(context.Context, uuid.UUID, func(*model.PositionUpdate) error) error
+	ReconcilePositions(context.Context) (*model.ReconciliationReport, error)
+	GetMarginLevel(context.Context, uuid.UUID) (*model.MarginLevel, error)
+	ListClosedTrades(context.Context, *model.ClosedTradeFilter) ([]*model.Position, uuid.UUID, error)
+	PlaceOrder(context.Context, *model.Order, bool) ([]*model.Order, error)
+	CancelOrder(context.Context, uuid.UUID) error
+	AmendOrder(context.Context, uuid.UUID, *model.OrderAmendment) (*model.Order, error)
+	ListOrders(context.Context, *model.OrderFilter) ([]*model.Order, uuid.UUID, error)
+	CreateInstrument(context.Context, *model.Instrument) error
+	UpdateInstrument(context.Context, *model.Instrument) (*model.Instrument, error)
+	GetInstrument(string) (*model.Instrument, error)
+	GetTradableInstrument(string) (*model.Instrument, error)
+	ListInstruments(bool) []*model.Instrument
+	GetRiskLimits(context.Context, uuid.UUID) (*model.RiskLimits, error)
+	SetRiskLimits(context.Context, *model.RiskLimits) error
 }
 
-// customValidator function for validation of requests
-func (h *TradingHandler) customValidator(ctx context.Context, i interface{}) error {
-	if val, ok := i.(*model.Position); ok {
+// customValidator function for validation of requests, field is a name of validated request field
+// Invalid field is returned as *model.ValidationError
+func (h *TradingHandler) customValidator(ctx context.Context, field string, i interface{}) error {
+	switch val := i.(type) {
+	case *model.Position:
 		err := h.vl.VarCtx(ctx, val.ID, "required")
 		if err != nil {
-			return fmt.Errorf("VarCtx: %w", err)
+			return model.NewValidationError("ID", "ID is required")
+		}
+		err = h.validateShareName(ctx, val.ShareName)
+		if err != nil {
+			return fmt.Errorf("validateShareName: %w", err)
 		}
-		err = h.vl.VarCtx(ctx, val.ShareName, "required")
+	case *model.Order:
+		err := h.validateShareName(ctx, val.ShareName)
 		if err != nil {
-			return fmt.Errorf("VarCtx: %w", err)
+			return fmt.Errorf("validateShareName: %w", err)
 		}
-	} else {
+	default:
 		err := h.vl.VarCtx(ctx, i, "required")
 		if err != nil {
-			return fmt.Errorf("VarCtx: %w", err)
+			return model.NewValidationError(field, "%s is required", field)
 		}
 	}
 	return nil
 }
 
+// validateShareName function checks that share is present in instrument registry and can be traded
+func (h *TradingHandler) validateShareName(ctx context.Context, shareName string) error {
+	err := h.vl.VarCtx(ctx, shareName, "required")
+	if err != nil {
+		return model.NewValidationError("shareName", "share name is required")
+	}
+	_, err = h.srv.GetTradableInstrument(shareName)
+	if err != nil {
+		return fmt.Errorf("GetTradableInstrument: %w", err)
+	}
+	return nil
+}
+
 // OpenPosition function opens position for user
 func (h *TradingHandler) OpenPosition(ctx context.Context, req *proto.OpenPositionRequest) (*proto.OpenPositionResponse, error) {
-	ID, err := uuid.Parse(req.Position.Id)
+	if req.Position == nil {
+		return nil, errorStatus(model.NewValidationError("position", "position is required"))
+	}
+	ID, err := parseUUID("id", req.Position.Id)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"ID": req.Position.Id}).Errorf("Parse: %v", err)
-		return nil, fmt.Errorf("parse: %w", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
 	}
+	parser := &decimalParser{}
 	position := &model.Position{
-		ID:          uuid.New(),
-		ProfileID:   ID,
-		IsLong:      req.Position.IsLong,
-		ShareName:   req.Position.ShareName,
-		Total:       req.Position.Total,
-		ShareAmount: req.Position.ShareAmount,
-		StopLoss:    req.Position.StopLoss,
-		TakeProfit:  req.Position.TakeProfit,
+		ID:                   uuid.New(),
+		ProfileID:            ID,
+		IsLong:               req.Position.IsLong,
+		ShareName:            req.Position.ShareName,
+		Total:                parser.parse("total", req.Position.Total),
+		ShareAmount:          parser.parse("shareAmount", req.Position.ShareAmount),
+		StopLoss:             parser.parse("stopLoss", req.Position.StopLoss),
+		TakeProfit:           parser.parse("takeProfit", req.Position.TakeProfit),
+		TrailingStopType:     trailingStopTypeFromProto(req.Position.TrailingStopType),
+		TrailingStopDistance: parser.parse("trailingStopDistance", req.Position.TrailingStopDistance),
+		Leverage:             parser.parse("leverage", req.Position.Leverage),
+		ExpiresAt:            timeFromProto(req.Position.ExpiresAt),
+	}
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"position": req.Position}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
 	}
-	err = h.customValidator(ctx, position)
+	err = h.customValidator(ctx, "position", position)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"position": position}).Errorf("customValidator: %v", err)
-		return nil, fmt.Errorf("customValidator: %w", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
 	}
-	err = h.srv.OpenPosition(ctx, position)
+	err = h.srv.OpenPosition(ctx, position, idempotencyKey(ctx, req.IdempotencyKey))
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"position": position}).Errorf("OpenPosition: %v", err)
-		return nil, fmt.Errorf("OpenPosition: %w", err)
+		return nil, errorStatus(fmt.Errorf("OpenPosition: %w", err))
 	}
 
 	return &proto.OpenPositionResponse{ID: position.ID.String()}, nil
@@ -83,15 +140,757 @@ func (h *TradingHandler) OpenPosition(ctx context.Context, req *proto.OpenPositi
 
 // ClosePosition function closes position for user
 func (h *TradingHandler) ClosePosition(ctx context.Context, req *proto.ClosePositionRequest) (*proto.ClosePositionResponse, error) {
-	ID, err := uuid.Parse(req.ID)
+	ID, err := parseUUID("ID", req.ID)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"ID": req.ID}).Errorf("Parse: %v", err)
-		return nil, fmt.Errorf("parse: %w", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
 	}
-	profitAndLoss, err := h.srv.ClosePosition(ctx, ID)
+	parser := &decimalParser{}
+	part := model.ClosePart{ShareAmount: parser.parse("shareAmount", req.ShareAmount), Percent: parser.parse("percent", req.Percent)}
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"ShareAmount": req.ShareAmount, "Percent": req.Percent}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
+	}
+	profitAndLoss, err := h.srv.ClosePosition(ctx, ID, part, idempotencyKey(ctx, req.IdempotencyKey))
 	if err != nil {
 		logrus.WithFields(logrus.Fields{"ID": ID}).Errorf("ClosePosition: %v", err)
-		return nil, fmt.Errorf("ClosePosition: %w", err)
+		return nil, errorStatus(fmt.Errorf("ClosePosition: %w", err))
+	}
+	return &proto.ClosePositionResponse{
+		PnL:         profitAndLoss.GrossPercent.String(),
+		NetPnL:      profitAndLoss.NetPercent.String(),
+		GrossProfit: profitAndLoss.Gross.String(),
+		NetProfit:   profitAndLoss.Net.String(),
+		Commission:  profitAndLoss.Commission.String(),
+	}, nil
+}
+
+// IncreasePosition function buys more shares into user's position for given amount of money
+func (h *TradingHandler) IncreasePosition(ctx context.Context, req *proto.IncreasePositionRequest) (*proto.IncreasePositionResponse, error) {
+	ID, err := parseUUID("ID", req.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": req.ID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	parser := &decimalParser{}
+	total := parser.parse("total", req.Total)
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"Total": req.Total}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
+	}
+	position, err := h.srv.IncreasePosition(ctx, ID, total)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": ID, "Total": req.Total}).Errorf("IncreasePosition: %v", err)
+		return nil, errorStatus(fmt.Errorf("IncreasePosition: %w", err))
+	}
+	return &proto.IncreasePositionResponse{
+		SharePrice:  position.SharePrice.String(),
+		ShareAmount: position.ShareAmount.String(),
+		Total:       position.Total.String(),
+	}, nil
+}
+
+// GetPosition function returns position of given ID with its current price and unrealized PnL
+func (h *TradingHandler) GetPosition(ctx context.Context, req *proto.GetPositionRequest) (*proto.GetPositionResponse, error) {
+	ID, err := parseUUID("ID", req.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": req.ID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	details, err := h.srv.GetPosition(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": ID}).Errorf("GetPosition: %v", err)
+		return nil, errorStatus(fmt.Errorf("GetPosition: %w", err))
+	}
+	return &proto.GetPositionResponse{Position: positionDetailsToProto(details)}, nil
+}
+
+// ListPositions function returns a page of user's positions filtered by share name and direction
+func (h *TradingHandler) ListPositions(ctx context.Context, req *proto.ListPositionsRequest) (*proto.ListPositionsResponse, error) {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	filter := &model.PositionFilter{
+		ProfileID: profileID,
+		ShareName: req.ShareName,
+		Limit:     int(req.Limit),
+	}
+	if req.Cursor != "" {
+		filter.Cursor, err = parseUUID("cursor", req.Cursor)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"Cursor": req.Cursor}).Errorf("Parse: %v", err)
+			return nil, errorStatus(fmt.Errorf("parse: %w", err))
+		}
+	}
+	switch req.Direction {
+	case proto.Direction_DIRECTION_LONG:
+		isLong := true
+		filter.IsLong = &isLong
+	case proto.Direction_DIRECTION_SHORT:
+		isLong := false
+		filter.IsLong = &isLong
+	}
+	err = h.customValidator(ctx, "profileID", filter.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	positions, nextCursor, err := h.srv.ListPositions(ctx, filter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("ListPositions: %v", err)
+		return nil, errorStatus(fmt.Errorf("ListPositions: %w", err))
+	}
+	response := &proto.ListPositionsResponse{Positions: make([]*proto.PositionDetails, 0, len(positions))}
+	for _, details := range positions {
+		response.Positions = append(response.Positions, positionDetailsToProto(details))
+	}
+	if nextCursor != uuid.Nil {
+		response.NextCursor = nextCursor.String()
+	}
+	return response, nil
+}
+
+// StreamPositions function streams live updates of all opened positions of the user
+func (h *TradingHandler) StreamPositions(req *proto.StreamPositionsRequest, stream proto.TradingService_StreamPositionsServer) error {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	err = h.srv.StreamPositions(stream.Context(), profileID, func(update *model.PositionUpdate) error {
+		return stream.Send(positionUpdateToProto(update))
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": profileID}).Errorf("StreamPositions: %v", err)
+		return errorStatus(fmt.Errorf("StreamPositions: %w", err))
+	}
+	return nil
+}
+
+// ReconcilePositions function returns positions which are present only in database or only in memory of the service
+func (h *TradingHandler) ReconcilePositions(ctx context.Context, _ *proto.ReconcilePositionsRequest) (*proto.ReconcilePositionsResponse, error) {
+	report, err := h.srv.ReconcilePositions(ctx)
+	if err != nil {
+		logrus.Errorf("ReconcilePositions: %v", err)
+		return nil, errorStatus(fmt.Errorf("ReconcilePositions: %w", err))
+	}
+	response := &proto.ReconcilePositionsResponse{UnavailableShares: report.UnavailableShares}
+	for _, ID := range report.OnlyInDatabase {
+		response.OnlyInDatabase = append(response.OnlyInDatabase, ID.String())
+	}
+	for _, ID := range report.OnlyInManager {
+		response.OnlyInManager = append(response.OnlyInManager, ID.String())
+	}
+	return response, nil
+}
+
+// GetMarginLevel function returns margin state of user's profile by current share prices
+func (h *TradingHandler) GetMarginLevel(ctx context.Context, req *proto.GetMarginLevelRequest) (*proto.GetMarginLevelResponse, error) {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	marginLevel, err := h.srv.GetMarginLevel(ctx, profileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": profileID}).Errorf("GetMarginLevel: %v", err)
+		return nil, errorStatus(fmt.Errorf("GetMarginLevel: %w", err))
+	}
+	return &proto.GetMarginLevelResponse{
+		Balance:           marginLevel.Balance.String(),
+		Equity:            marginLevel.Equity.String(),
+		UsedMargin:        marginLevel.UsedMargin.String(),
+		MaintenanceMargin: marginLevel.MaintenanceMargin.String(),
+		MarginLevel:       marginLevel.Level.String(),
+		MarginCall:        marginLevel.MarginCall,
+	}, nil
+}
+
+// ListClosedTrades function returns a page of user's closed positions within given period, newest first
+func (h *TradingHandler) ListClosedTrades(ctx context.Context, req *proto.ListClosedTradesRequest) (*proto.ListClosedTradesResponse, error) {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	filter := &model.ClosedTradeFilter{
+		ProfileID: profileID,
+		Limit:     int(req.Limit),
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+	if req.Cursor != "" {
+		filter.Cursor, err = parseUUID("cursor", req.Cursor)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"Cursor": req.Cursor}).Errorf("Parse: %v", err)
+			return nil, errorStatus(fmt.Errorf("parse: %w", err))
+		}
+	}
+	err = h.customValidator(ctx, "profileID", filter.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	trades, nextCursor, err := h.srv.ListClosedTrades(ctx, filter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("ListClosedTrades: %v", err)
+		return nil, errorStatus(fmt.Errorf("ListClosedTrades: %w", err))
+	}
+	response := &proto.ListClosedTradesResponse{Trades: make([]*proto.ClosedTrade, 0, len(trades))}
+	for _, trade := range trades {
+		response.Trades = append(response.Trades, closedTradeToProto(trade))
+	}
+	if nextCursor != uuid.Nil {
+		response.NextCursor = nextCursor.String()
+	}
+	return response, nil
+}
+
+// closedTradeToProto function converts closed position model to its proto message
+func closedTradeToProto(position *model.Position) *proto.ClosedTrade {
+	trade := &proto.ClosedTrade{
+		ID:          position.ID.String(),
+		ShareName:   position.ShareName,
+		IsLong:      position.IsLong,
+		SharePrice:  position.SharePrice.String(),
+		ExitPrice:   position.ExitPrice.String(),
+		ShareAmount: position.ShareAmount.String(),
+		Total:       position.Total.String(),
+		RealizedPnL: position.RealizedPnL.String(),
+		CloseReason: string(position.CloseReason),
+		OpenedAt:    timestamppb.New(position.OpenedAt),
+		OpenFee:     position.OpenFee.String(),
+		CloseFee:    position.CloseFee.String(),
+		NetPnL:      position.NetPnL().String(),
+	}
+	if position.ClosedAt != nil {
+		trade.ClosedAt = timestamppb.New(*position.ClosedAt)
+	}
+	if position.ParentID != nil {
+		trade.ParentID = position.ParentID.String()
+	}
+	return trade
+}
+
+// PlaceOrder function places a pending entry order for user, bracket order also places its take profit and stop loss exit orders
+func (h *TradingHandler) PlaceOrder(ctx context.Context, req *proto.PlaceOrderRequest) (*proto.PlaceOrderResponse, error) {
+	if req.Order == nil {
+		return nil, errorStatus(model.NewValidationError("order", "order is required"))
+	}
+	profileID, err := parseUUID("profileID", req.Order.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.Order.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	parser := &decimalParser{}
+	order := &model.Order{
+		ID:           uuid.New(),
+		ProfileID:    profileID,
+		ShareName:    req.Order.ShareName,
+		Type:         orderTypeFromProto(req.Order.Type),
+		TriggerPrice: parser.parse("triggerPrice", req.Order.TriggerPrice),
+		Total:        parser.parse("total", req.Order.Total),
+		StopLoss:     parser.parse("stopLoss", req.Order.StopLoss),
+		TakeProfit:   parser.parse("takeProfit", req.Order.TakeProfit),
+		TimeInForce:  timeInForceFromProto(req.Order.TimeInForce),
+		ExpiresAt:    timeFromProto(req.Order.ExpiresAt),
+	}
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"order": req.Order}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
+	}
+	err = h.customValidator(ctx, "order", order)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"order": order}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	exits, err := h.srv.PlaceOrder(ctx, order, req.Bracket)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"order": order}).Errorf("PlaceOrder: %v", err)
+		return nil, errorStatus(fmt.Errorf("PlaceOrder: %w", err))
+	}
+	response := &proto.PlaceOrderResponse{ID: order.ID.String(), Status: string(order.Status)}
+	for _, exit := range exits {
+		if exit.Type == model.OrderBuyLimit || exit.Type == model.OrderSellLimit {
+			response.TakeProfitID = exit.ID.String()
+		} else {
+			response.StopLossID = exit.ID.String()
+		}
+	}
+	return response, nil
+}
+
+// CancelOrder function cancels pending order of given ID
+func (h *TradingHandler) CancelOrder(ctx context.Context, req *proto.CancelOrderRequest) (*proto.CancelOrderResponse, error) {
+	ID, err := parseUUID("ID", req.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": req.ID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	err = h.srv.CancelOrder(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": ID}).Errorf("CancelOrder: %v", err)
+		return nil, errorStatus(fmt.Errorf("CancelOrder: %w", err))
+	}
+	return &proto.CancelOrderResponse{}, nil
+}
+
+// AmendOrder function changes trigger price and time-in-force of pending or inactive order of given ID, unset fields are left unchanged
+func (h *TradingHandler) AmendOrder(ctx context.Context, req *proto.AmendOrderRequest) (*proto.AmendOrderResponse, error) {
+	ID, err := parseUUID("ID", req.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": req.ID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	parser := &decimalParser{}
+	amendment := &model.OrderAmendment{
+		TriggerPrice: parser.parse("triggerPrice", req.TriggerPrice),
+		TimeInForce:  timeInForceFromProto(req.TimeInForce),
+		ExpiresAt:    timeFromProto(req.ExpiresAt),
+	}
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"TriggerPrice": req.TriggerPrice}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
+	}
+	order, err := h.srv.AmendOrder(ctx, ID, amendment)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ID": ID, "amendment": amendment}).Errorf("AmendOrder: %v", err)
+		return nil, errorStatus(fmt.Errorf("AmendOrder: %w", err))
+	}
+	return &proto.AmendOrderResponse{Order: orderToProto(order)}, nil
+}
+
+// ListOrders function returns a page of user's orders filtered by status
+func (h *TradingHandler) ListOrders(ctx context.Context, req *proto.ListOrdersRequest) (*proto.ListOrdersResponse, error) {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	filter := &model.OrderFilter{
+		ProfileID: profileID,
+		Status:    model.OrderStatus(req.Status),
+		Limit:     int(req.Limit),
+	}
+	if req.Cursor != "" {
+		filter.Cursor, err = parseUUID("cursor", req.Cursor)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"Cursor": req.Cursor}).Errorf("Parse: %v", err)
+			return nil, errorStatus(fmt.Errorf("parse: %w", err))
+		}
+	}
+	err = h.customValidator(ctx, "profileID", filter.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	orders, nextCursor, err := h.srv.ListOrders(ctx, filter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"filter": filter}).Errorf("ListOrders: %v", err)
+		return nil, errorStatus(fmt.Errorf("ListOrders: %w", err))
+	}
+	response := &proto.ListOrdersResponse{Orders: make([]*proto.Order, 0, len(orders))}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, orderToProto(order))
+	}
+	if nextCursor != uuid.Nil {
+		response.NextCursor = nextCursor.String()
+	}
+	return response, nil
+}
+
+// CreateInstrument function adds a new tradable share to instrument registry
+func (h *TradingHandler) CreateInstrument(ctx context.Context, req *proto.CreateInstrumentRequest) (*proto.CreateInstrumentResponse, error) {
+	instrument, err := instrumentFromProto(req.Instrument)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"instrument": req.Instrument}).Errorf("instrumentFromProto: %v", err)
+		return nil, errorStatus(fmt.Errorf("instrumentFromProto: %w", err))
+	}
+	err = h.customValidator(ctx, "shareName", instrument.ShareName)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"instrument": instrument}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	err = h.srv.CreateInstrument(ctx, instrument)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"instrument": instrument}).Errorf("CreateInstrument: %v", err)
+		return nil, errorStatus(fmt.Errorf("CreateInstrument: %w", err))
+	}
+	return &proto.CreateInstrumentResponse{Instrument: instrumentToProto(instrument)}, nil
+}
+
+// UpdateInstrument function replaces trading rules of a share in instrument registry
+func (h *TradingHandler) UpdateInstrument(ctx context.Context, req *proto.UpdateInstrumentRequest) (*proto.UpdateInstrumentResponse, error) {
+	instrument, err := instrumentFromProto(req.Instrument)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"instrument": req.Instrument}).Errorf("instrumentFromProto: %v", err)
+		return nil, errorStatus(fmt.Errorf("instrumentFromProto: %w", err))
+	}
+	err = h.customValidator(ctx, "shareName", instrument.ShareName)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"instrument": instrument}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	instrument, err = h.srv.UpdateInstrument(ctx, instrument)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ShareName": req.Instrument.ShareName}).Errorf("UpdateInstrument: %v", err)
+		return nil, errorStatus(fmt.Errorf("UpdateInstrument: %w", err))
+	}
+	return &proto.UpdateInstrumentResponse{Instrument: instrumentToProto(instrument)}, nil
+}
+
+// GetInstrument function returns trading rules of a share
+func (h *TradingHandler) GetInstrument(ctx context.Context, req *proto.GetInstrumentRequest) (*proto.GetInstrumentResponse, error) {
+	err := h.customValidator(ctx, "shareName", req.ShareName)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ShareName": req.ShareName}).Errorf("customValidator: %v", err)
+		return nil, errorStatus(fmt.Errorf("customValidator: %w", err))
+	}
+	instrument, err := h.srv.GetInstrument(req.ShareName)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ShareName": req.ShareName}).Errorf("GetInstrument: %v", err)
+		return nil, errorStatus(fmt.Errorf("GetInstrument: %w", err))
+	}
+	return &proto.GetInstrumentResponse{Instrument: instrumentToProto(instrument)}, nil
+}
+
+// ListInstruments function returns all the shares of instrument registry or only tradable ones
+func (h *TradingHandler) ListInstruments(_ context.Context, req *proto.ListInstrumentsRequest) (*proto.ListInstrumentsResponse, error) {
+	instruments := h.srv.ListInstruments(req.TradableOnly)
+	response := &proto.ListInstrumentsResponse{Instruments: make([]*proto.Instrument, 0, len(instruments))}
+	for _, instrument := range instruments {
+		response.Instruments = append(response.Instruments, instrumentToProto(instrument))
+	}
+	return response, nil
+}
+
+// GetRiskLimits function returns risk limits of user's profile
+func (h *TradingHandler) GetRiskLimits(ctx context.Context, req *proto.GetRiskLimitsRequest) (*proto.GetRiskLimitsResponse, error) {
+	profileID, err := parseUUID("profileID", req.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	limits, err := h.srv.GetRiskLimits(ctx, profileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": profileID}).Errorf("GetRiskLimits: %v", err)
+		return nil, errorStatus(fmt.Errorf("GetRiskLimits: %w", err))
+	}
+	return &proto.GetRiskLimitsResponse{Limits: riskLimitsToProto(limits)}, nil
+}
+
+// SetRiskLimits function replaces risk limits of user's profile, zero limit means that it is not limited
+func (h *TradingHandler) SetRiskLimits(ctx context.Context, req *proto.SetRiskLimitsRequest) (*proto.SetRiskLimitsResponse, error) {
+	if req.Limits == nil {
+		return nil, errorStatus(model.NewValidationError("limits", "limits are required"))
+	}
+	profileID, err := parseUUID("profileID", req.Limits.ProfileID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ProfileID": req.Limits.ProfileID}).Errorf("Parse: %v", err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", err))
+	}
+	parser := &decimalParser{}
+	limits := &model.RiskLimits{
+		ProfileID:             profileID,
+		MaxOpenPositions:      int(req.Limits.MaxOpenPositions),
+		MaxInstrumentNotional: parser.parse("maxInstrumentNotional", req.Limits.MaxInstrumentNotional),
+		MaxTotalExposure:      parser.parse("maxTotalExposure", req.Limits.MaxTotalExposure),
+		MaxDailyLoss:          parser.parse("maxDailyLoss", req.Limits.MaxDailyLoss),
+		MaxOrderSize:          parser.parse("maxOrderSize", req.Limits.MaxOrderSize),
+	}
+	if parser.err != nil {
+		logrus.WithFields(logrus.Fields{"limits": req.Limits}).Errorf("parse: %v", parser.err)
+		return nil, errorStatus(fmt.Errorf("parse: %w", parser.err))
+	}
+	err = h.srv.SetRiskLimits(ctx, limits)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"limits": limits}).Errorf("SetRiskLimits: %v", err)
+		return nil, errorStatus(fmt.Errorf("SetRiskLimits: %w", err))
+	}
+	return &proto.SetRiskLimitsResponse{Limits: riskLimitsToProto(limits)}, nil
+}
+
+// riskLimitsToProto function converts risk limits model to its proto message
+func riskLimitsToProto(limits *model.RiskLimits) *proto.RiskLimits {
+	protoLimits := &proto.RiskLimits{
+		ProfileID:             limits.ProfileID.String(),
+		MaxOpenPositions:      int32(limits.MaxOpenPositions),
+		MaxInstrumentNotional: limits.MaxInstrumentNotional.String(),
+		MaxTotalExposure:      limits.MaxTotalExposure.String(),
+		MaxDailyLoss:          limits.MaxDailyLoss.String(),
+		MaxOrderSize:          limits.MaxOrderSize.String(),
+	}
+	if !limits.UpdatedAt.IsZero() {
+		protoLimits.UpdatedAt = timestamppb.New(limits.UpdatedAt)
+	}
+	return protoLimits
+}
+
+// idempotencyKey function returns idempotency key of the request from its field or from idempotencyKeyHeader of gRPC metadata
+func idempotencyKey(ctx context.Context, requestKey string) string {
+	if requestKey != "" {
+		return requestKey
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			return keys[0]
+		}
+	}
+	return ""
+}
+
+// instrumentFromProto function converts proto instrument to its model
+func instrumentFromProto(instrument *proto.Instrument) (*model.Instrument, error) {
+	if instrument == nil {
+		return nil, model.NewValidationError("instrument", "instrument is required")
+	}
+	parser := &decimalParser{}
+	result := &model.Instrument{
+		ShareName:     instrument.ShareName,
+		TickSize:      parser.parse("tickSize", instrument.TickSize),
+		MinLot:        parser.parse("minLot", instrument.MinLot),
+		QuantityScale: instrument.QuantityScale,
+		Currency:      instrument.Currency,
+		MinOrderValue: parser.parse("minOrderValue", instrument.MinOrderValue),
+		MaxOrderValue: parser.parse("maxOrderValue", instrument.MaxOrderValue),
+		Tradable:      instrument.Tradable,
+	}
+	if parser.err != nil {
+		return nil, parser.err
+	}
+	return result, nil
+}
+
+// instrumentToProto function converts instrument model to its proto message
+func instrumentToProto(instrument *model.Instrument) *proto.Instrument {
+	return &proto.Instrument{
+		ShareName:     instrument.ShareName,
+		TickSize:      instrument.TickSize.String(),
+		MinLot:        instrument.MinLot.String(),
+		QuantityScale: instrument.QuantityScale,
+		Currency:      instrument.Currency,
+		MinOrderValue: instrument.MinOrderValue.String(),
+		MaxOrderValue: instrument.MaxOrderValue.String(),
+		Tradable:      instrument.Tradable,
+		CreatedAt:     timestamppb.New(instrument.CreatedAt),
+		UpdatedAt:     timestamppb.New(instrument.UpdatedAt),
+	}
+}
+
+// orderTypeFromProto function converts proto order type to its model, unknown type is rejected by service
+func orderTypeFromProto(orderType proto.OrderType) model.OrderType {
+	switch orderType {
+	case proto.OrderType_ORDER_TYPE_BUY_LIMIT:
+		return model.OrderBuyLimit
+	case proto.OrderType_ORDER_TYPE_SELL_LIMIT:
+		return model.OrderSellLimit
+	case proto.OrderType_ORDER_TYPE_BUY_STOP:
+		return model.OrderBuyStop
+	case proto.OrderType_ORDER_TYPE_SELL_STOP:
+		return model.OrderSellStop
+	}
+	return ""
+}
+
+// orderToProto function converts order model to its proto message
+func orderToProto(order *model.Order) *proto.Order {
+	orderType := proto.OrderType_ORDER_TYPE_UNSPECIFIED
+	switch order.Type {
+	case model.OrderBuyLimit:
+		orderType = proto.OrderType_ORDER_TYPE_BUY_LIMIT
+	case model.OrderSellLimit:
+		orderType = proto.OrderType_ORDER_TYPE_SELL_LIMIT
+	case model.OrderBuyStop:
+		orderType = proto.OrderType_ORDER_TYPE_BUY_STOP
+	case model.OrderSellStop:
+		orderType = proto.OrderType_ORDER_TYPE_SELL_STOP
+	}
+	protoOrder := &proto.Order{
+		ID:           order.ID.String(),
+		ProfileID:    order.ProfileID.String(),
+		ShareName:    order.ShareName,
+		Type:         orderType,
+		TriggerPrice: order.TriggerPrice.String(),
+		Total:        order.Total.String(),
+		StopLoss:     order.StopLoss.String(),
+		TakeProfit:   order.TakeProfit.String(),
+		Status:       string(order.Status),
+		PositionID:   order.PositionID.String(),
+		Error:        order.Error,
+		CreatedAt:    timestamppb.New(order.CreatedAt),
+		TimeInForce:  timeInForceToProto(order.TimeInForce),
+		ExpiresAt:    timeToProto(order.ExpiresAt),
+	}
+	if order.ParentID != nil {
+		protoOrder.ParentID = order.ParentID.String()
+	}
+	return protoOrder
+}
+
+// timeInForceFromProto function converts proto time-in-force to its model, unspecified time-in-force is empty
+func timeInForceFromProto(timeInForce proto.TimeInForce) model.TimeInForce {
+	switch timeInForce {
+	case proto.TimeInForce_TIME_IN_FORCE_GTC:
+		return model.TimeInForceGTC
+	case proto.TimeInForce_TIME_IN_FORCE_GTD:
+		return model.TimeInForceGTD
+	case proto.TimeInForce_TIME_IN_FORCE_DAY:
+		return model.TimeInForceDAY
+	case proto.TimeInForce_TIME_IN_FORCE_IOC:
+		return model.TimeInForceIOC
+	case proto.TimeInForce_TIME_IN_FORCE_FOK:
+		return model.TimeInForceFOK
+	}
+	return ""
+}
+
+// timeInForceToProto function converts time-in-force model to its proto enum
+func timeInForceToProto(timeInForce model.TimeInForce) proto.TimeInForce {
+	switch timeInForce {
+	case model.TimeInForceGTC:
+		return proto.TimeInForce_TIME_IN_FORCE_GTC
+	case model.TimeInForceGTD:
+		return proto.TimeInForce_TIME_IN_FORCE_GTD
+	case model.TimeInForceDAY:
+		return proto.TimeInForce_TIME_IN_FORCE_DAY
+	case model.TimeInForceIOC:
+		return proto.TimeInForce_TIME_IN_FORCE_IOC
+	case model.TimeInForceFOK:
+		return proto.TimeInForce_TIME_IN_FORCE_FOK
+	}
+	return proto.TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+// decimalParser struct parses decimal fields of a request and keeps the first error
+type decimalParser struct {
+	err error
+}
+
+// parse method parses decimal value of the named field, empty value is zero
+func (p *decimalParser) parse(name, value string) decimal.Decimal {
+	if p.err != nil || value == "" {
+		return decimal.Zero
+	}
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		p.err = model.NewValidationError(name, "%q is not a valid decimal", value)
+		return decimal.Zero
+	}
+	return parsed
+}
+
+// parseUUID function parses UUID value of the named field
+func parseUUID(name, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, model.NewValidationError(name, "%q is not a valid UUID", value)
+	}
+	return id, nil
+}
+
+// timeFromProto function converts optional proto timestamp to time, unset timestamp is nil
+func timeFromProto(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t := timestamp.AsTime()
+	return &t
+}
+
+// timeToProto function converts optional time to proto timestamp
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// trailingStopTypeFromProto function converts proto trailing stop type to its model
+func trailingStopTypeFromProto(trailingStopType proto.TrailingStopType) model.TrailingStopType {
+	switch trailingStopType {
+	case proto.TrailingStopType_TRAILING_STOP_AMOUNT:
+		return model.TrailingStopAmount
+	case proto.TrailingStopType_TRAILING_STOP_PERCENT:
+		return model.TrailingStopPercent
+	}
+	return model.TrailingStopNone
+}
+
+// trailingStopTypeToProto function converts trailing stop type model to its proto enum
+func trailingStopTypeToProto(trailingStopType model.TrailingStopType) proto.TrailingStopType {
+	switch trailingStopType {
+	case model.TrailingStopAmount:
+		return proto.TrailingStopType_TRAILING_STOP_AMOUNT
+	case model.TrailingStopPercent:
+		return proto.TrailingStopType_TRAILING_STOP_PERCENT
+	}
+	return proto.TrailingStopType_TRAILING_STOP_NONE
+}
+
+// positionUpdateToProto function converts position update model to its proto message
+func positionUpdateToProto(update *model.PositionUpdate) *proto.PositionUpdate {
+	updateType := proto.PositionUpdateType_POSITION_UPDATE_PRICE
+	switch update.Type {
+	case model.PositionUpdateOpened:
+		updateType = proto.PositionUpdateType_POSITION_UPDATE_OPENED
+	case model.PositionUpdateClosed:
+		updateType = proto.PositionUpdateType_POSITION_UPDATE_CLOSED
+	case model.PositionUpdateReduced:
+		updateType = proto.PositionUpdateType_POSITION_UPDATE_REDUCED
+	case model.PositionUpdateIncreased:
+		updateType = proto.PositionUpdateType_POSITION_UPDATE_INCREASED
+	case model.PositionUpdateMarginCall:
+		updateType = proto.PositionUpdateType_POSITION_UPDATE_MARGIN_CALL
+	}
+	return &proto.PositionUpdate{
+		Type:                 updateType,
+		PositionID:           update.PositionID.String(),
+		ShareName:            update.ShareName,
+		IsLong:               update.IsLong,
+		SharePrice:           update.SharePrice.String(),
+		MarkPrice:            update.MarkPrice.String(),
+		ShareAmount:          update.ShareAmount.String(),
+		UnrealizedPnL:        update.UnrealizedPnL.String(),
+		UnrealizedPnLPercent: update.UnrealizedPnLPercent.String(),
+		StopLoss:             update.StopLoss.String(),
+		TakeProfit:           update.TakeProfit.String(),
+		StopLossDistance:     update.StopLossDistance.String(),
+		TakeProfitDistance:   update.TakeProfitDistance.String(),
+		CloseReason:          string(update.CloseReason),
+		PnL:                  update.PnL.String(),
+		MarginLevel:          update.MarginLevel.String(),
+	}
+}
+
+// positionDetailsToProto function converts position details model to its proto message
+func positionDetailsToProto(details *model.PositionDetails) *proto.PositionDetails {
+	return &proto.PositionDetails{
+		ID:                   details.Position.ID.String(),
+		ProfileID:            details.Position.ProfileID.String(),
+		IsLong:               details.Position.IsLong,
+		ShareName:            details.Position.ShareName,
+		SharePrice:           details.Position.SharePrice.String(),
+		MarkPrice:            details.MarkPrice.String(),
+		Total:                details.Position.Total.String(),
+		ShareAmount:          details.Position.ShareAmount.String(),
+		StopLoss:             details.Position.StopLoss.String(),
+		TakeProfit:           details.Position.TakeProfit.String(),
+		UnrealizedPnL:        details.UnrealizedPnL.String(),
+		UnrealizedPnLPercent: details.UnrealizedPnLPercent.String(),
+		TrailingStopType:     trailingStopTypeToProto(details.Position.TrailingStopType),
+		TrailingStopDistance: details.Position.TrailingStopDistance.String(),
+		Leverage:             details.Position.Leverage.String(),
+		ExpiresAt:            timeToProto(details.Position.ExpiresAt),
+		Status:               string(details.Position.Status),
+		ExitPrice:            details.Position.ExitPrice.String(),
+		RealizedPnL:          details.Position.RealizedPnL.String(),
+		CloseReason:          string(details.Position.CloseReason),
+		ClosedAt:             timeToProto(details.Position.ClosedAt),
 	}
-	return &proto.ClosePositionResponse{PnL: profitAndLoss}, nil
 }
@@ -0,0 +1,278 @@
+// Package migrations contains embedded SQL migrations of trading schema and applies them to the database
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// sqlFiles contains migrations named "<version>_<name>.up.sql" and "<version>_<name>.down.sql"
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// migrationLockID is a key of postgres advisory lock which keeps several instances of the service from migrating at once
+const migrationLockID = 7346283512
+
+// Migration struct represents one version of trading schema with SQL applying and reverting it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus struct represents a migration and the time it was applied, nil if it is not applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator struct applies and reverts embedded migrations, applied versions are stored in trading.schema_migrations
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []*Migration
+}
+
+// NewMigrator creates a new Migrator with embedded migrations
+func NewMigrator(pool *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("loadMigrations: %w", err)
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Up method applies all the migrations which are not applied yet in order of their versions
+// Each migration is applied in its own transaction, so a failed migration leaves the schema at the previous version
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := getAppliedVersions(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("getAppliedVersions: %w", err)
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			err = runMigration(ctx, conn, migration.Up,
+				"INSERT INTO trading.schema_migrations (version, name, applied_at) VALUES($1,$2,$3)", migration.Version, migration.Name, time.Now())
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			logrus.WithFields(logrus.Fields{"Version": migration.Version, "Name": migration.Name}).Info("migration applied")
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("withLock: %w", err)
+	}
+	return applied, nil
+}
+
+// Down method reverts given number of the latest applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var reverted []*Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := getAppliedVersions(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("getAppliedVersions: %w", err)
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			err = runMigration(ctx, conn, migration.Down, "DELETE FROM trading.schema_migrations WHERE version=$1", migration.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			logrus.WithFields(logrus.Fields{"Version": migration.Version, "Name": migration.Name}).Info("migration reverted")
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return reverted, fmt.Errorf("withLock: %w", err)
+	}
+	return reverted, nil
+}
+
+// Status method returns all the embedded migrations with the time they were applied
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	var statuses []*MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := getAppliedVersions(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("getAppliedVersions: %w", err)
+		}
+		for _, migration := range m.migrations {
+			status := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := versions[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("withLock: %w", err)
+	}
+	return statuses, nil
+}
+
+// withLock method runs the function on a connection holding migration advisory lock
+// Schema and the table of applied versions are created first if they don't exist
+func (m *Migrator) withLock(ctx context.Context, run func(*pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("Acquire: %w", err)
+	}
+	defer conn.Release()
+	_, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	defer func() {
+		_, unlockErr := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+		if unlockErr != nil {
+			logrus.Errorf("pg_advisory_unlock: %v", unlockErr)
+		}
+	}()
+	_, err = conn.Exec(ctx, `CREATE SCHEMA IF NOT EXISTS trading;
+		CREATE TABLE IF NOT EXISTS trading.schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		)`)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return run(conn)
+}
+
+// getAppliedVersions function returns applied versions with the time they were applied
+func getAppliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, "SELECT version, applied_at FROM trading.schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		versions[version] = appliedAt
+	}
+	return versions, rows.Err()
+}
+
+// runMigration function executes SQL of a migration and records it in trading.schema_migrations within one transaction
+func runMigration(ctx context.Context, conn *pgxpool.Conn, migrationSQL, recordSQL string, args ...interface{}) (err error) {
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	_, err = tx.Exec(ctx, migrationSQL)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	_, err = tx.Exec(ctx, recordSQL, args...)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// loadMigrations function reads embedded migrations and sorts them by version
+// Every version must have both up and down SQL
+func loadMigrations() ([]*Migration, error) {
+	entries, err := sqlFiles.ReadDir("sql")
+	if err != nil {
+		return nil, fmt.Errorf("ReadDir: %w", err)
+	}
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base, direction, ok := parseFileName(fileName)
+		if !ok {
+			return nil, fmt.Errorf("migration file %s must be named <version>_<name>.up.sql or <version>_<name>.down.sql", fileName)
+		}
+		versionPart, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionPart)
+		if err != nil {
+			return nil, fmt.Errorf("Atoi: %w", err)
+		}
+		content, err := sqlFiles.ReadFile(path.Join("sql", fileName))
+		if err != nil {
+			return nil, fmt.Errorf("ReadFile: %w", err)
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have up and down SQL", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseFileName function splits migration file name into its base name and direction
+func parseFileName(fileName string) (base, direction string, ok bool) {
+	for _, direction := range []string{"up", "down"} {
+		suffix := "." + direction + ".sql"
+		if strings.HasSuffix(fileName, suffix) {
+			return strings.TrimSuffix(fileName, suffix), direction, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName  string
+		base      string
+		direction string
+		ok        bool
+	}{
+		{fileName: "0001_create_positions.up.sql", base: "0001_create_positions", direction: "up", ok: true},
+		{fileName: "0001_create_positions.down.sql", base: "0001_create_positions", direction: "down", ok: true},
+		{fileName: "0002_add.up.down.sql", base: "0002_add.up", direction: "down", ok: true},
+		{fileName: "0003_create_orders.sql", ok: false},
+		{fileName: "0004_create_orders.up.txt", ok: false},
+		{fileName: "", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			base, direction, ok := parseFileName(tt.fileName)
+			if base != tt.base || direction != tt.direction || ok != tt.ok {
+				t.Errorf("parseFileName(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.fileName, base, direction, ok, tt.base, tt.direction, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("loadMigrations returned no migrations")
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %s has version %d, want %d", migration.Name, migration.Version, i+1)
+		}
+		if migration.Name == "" {
+			t.Errorf("migration %d has no name", migration.Version)
+		}
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %d_%s must have up and down SQL", migration.Version, migration.Name)
+		}
+	}
+}
+
+// TestLegacyPositionsTable checks that the first migration keeps the shape of positions table created before migrations,
+// so it is a no-op on existing databases and later migrations add the rest of the columns
+func TestLegacyPositionsTable(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	first := migrations[0]
+	for _, column := range []string{"id", "profile_id", "is_long", "share_name", "share_price", "total", "shares_amount", "stop_loss", "take_profit"} {
+		if !strings.Contains(first.Up, "    "+column+" ") {
+			t.Errorf("migration %d_%s has no legacy column %s", first.Version, first.Name, column)
+		}
+	}
+	for _, column := range []string{"status", "exit_price", "realized_pnl", "opened_at", "trailing_stop_type", "parent_id", "leverage", "expires_at", "version"} {
+		if strings.Contains(first.Up, "    "+column+" ") {
+			t.Errorf("migration %d_%s must not create column %s, it is added by a later migration", first.Version, first.Name, column)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS trading.trading (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL,
+    is_long BOOLEAN NOT NULL,
+    share_name TEXT NOT NULL,
+    share_price DOUBLE PRECISION NOT NULL,
+    total DOUBLE PRECISION NOT NULL,
+    shares_amount DOUBLE PRECISION NOT NULL,
+    stop_loss DOUBLE PRECISION NOT NULL,
+    take_profit DOUBLE PRECISION NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trading.balance_operations;
+DROP TABLE IF EXISTS trading.sagas;
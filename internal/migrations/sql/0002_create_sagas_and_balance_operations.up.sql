@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS trading.sagas (
+    id UUID PRIMARY KEY,
+    type TEXT NOT NULL,
+    step TEXT NOT NULL,
+    status TEXT NOT NULL,
+    position JSONB NOT NULL,
+    amount NUMERIC NOT NULL DEFAULT 0,
+    share_price NUMERIC NOT NULL DEFAULT 0,
+    pnl NUMERIC NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sagas_pending_idx ON trading.sagas (updated_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS trading.balance_operations (
+    key UUID PRIMARY KEY,
+    profile_id UUID NOT NULL,
+    amount NUMERIC NOT NULL DEFAULT 0,
+    status TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS balance_operations_reserved_idx ON trading.balance_operations (profile_id) WHERE status = 'reserved';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trading.outbox_sequences;
+DROP TABLE IF EXISTS trading.outbox;
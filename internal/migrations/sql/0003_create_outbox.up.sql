@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS trading.outbox (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    profile_id UUID NOT NULL,
+    position_id UUID NOT NULL,
+    sequence BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    published_at TIMESTAMPTZ,
+    UNIQUE (profile_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON trading.outbox (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_published_at_idx ON trading.outbox (published_at) WHERE published_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS trading.outbox_sequences (
+    profile_id UUID PRIMARY KEY,
+    last_sequence BIGINT NOT NULL
+);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS trading.trading_closed_at_idx;
+DROP INDEX IF EXISTS trading.trading_profile_status_idx;
+
+DELETE FROM trading.trading WHERE status = 'closed';
+
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS opened_at,
+    DROP COLUMN IF EXISTS close_reason,
+    DROP COLUMN IF EXISTS realized_pnl,
+    DROP COLUMN IF EXISTS exit_price,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,10 @@
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'open',
+    ADD COLUMN IF NOT EXISTS exit_price NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS realized_pnl NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS close_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS opened_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS trading_profile_status_idx ON trading.trading (profile_id, status);
+CREATE INDEX IF NOT EXISTS trading_closed_at_idx ON trading.trading (profile_id, closed_at DESC, id DESC) WHERE status = 'closed';
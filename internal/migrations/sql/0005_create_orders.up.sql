@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS trading.orders (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL,
+    share_name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    trigger_price NUMERIC NOT NULL DEFAULT 0,
+    total NUMERIC NOT NULL DEFAULT 0,
+    stop_loss NUMERIC NOT NULL DEFAULT 0,
+    take_profit NUMERIC NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    position_id UUID NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_profile_idx ON trading.orders (profile_id, id);
+CREATE INDEX IF NOT EXISTS orders_pending_idx ON trading.orders (created_at) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS trailing_stop_distance,
+    DROP COLUMN IF EXISTS trailing_stop_type;
@@ -0,0 +1,3 @@
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS trailing_stop_type TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS trailing_stop_distance NUMERIC NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS leverage;
@@ -0,0 +1,2 @@
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS leverage NUMERIC NOT NULL DEFAULT 1;
@@ -0,0 +1,9 @@
+DELETE FROM trading.orders WHERE parent_id IS NOT NULL;
+
+DROP INDEX IF EXISTS trading.orders_pending_idx;
+CREATE INDEX orders_pending_idx ON trading.orders (created_at) WHERE status = 'pending';
+
+DROP INDEX IF EXISTS trading.orders_parent_idx;
+
+ALTER TABLE trading.orders
+    DROP COLUMN IF EXISTS parent_id;
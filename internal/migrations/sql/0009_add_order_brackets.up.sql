@@ -0,0 +1,7 @@
+ALTER TABLE trading.orders
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES trading.orders (id);
+
+CREATE INDEX IF NOT EXISTS orders_parent_idx ON trading.orders (parent_id);
+
+DROP INDEX IF EXISTS trading.orders_pending_idx;
+CREATE INDEX orders_pending_idx ON trading.orders (created_at) WHERE status IN ('pending', 'inactive');
@@ -0,0 +1,6 @@
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE trading.orders
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS time_in_force;
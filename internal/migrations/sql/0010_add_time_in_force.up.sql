@@ -0,0 +1,6 @@
+ALTER TABLE trading.orders
+    ADD COLUMN IF NOT EXISTS time_in_force TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+ALTER TABLE trading.trading
+    ALTER COLUMN share_price TYPE DOUBLE PRECISION USING share_price::DOUBLE PRECISION,
+    ALTER COLUMN total TYPE DOUBLE PRECISION USING total::DOUBLE PRECISION,
+    ALTER COLUMN shares_amount TYPE DOUBLE PRECISION USING shares_amount::DOUBLE PRECISION,
+    ALTER COLUMN stop_loss TYPE DOUBLE PRECISION USING stop_loss::DOUBLE PRECISION,
+    ALTER COLUMN take_profit TYPE DOUBLE PRECISION USING take_profit::DOUBLE PRECISION;
@@ -0,0 +1,6 @@
+ALTER TABLE trading.trading
+    ALTER COLUMN share_price TYPE NUMERIC USING share_price::NUMERIC,
+    ALTER COLUMN total TYPE NUMERIC USING total::NUMERIC,
+    ALTER COLUMN shares_amount TYPE NUMERIC USING shares_amount::NUMERIC,
+    ALTER COLUMN stop_loss TYPE NUMERIC USING stop_loss::NUMERIC,
+    ALTER COLUMN take_profit TYPE NUMERIC USING take_profit::NUMERIC;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS trading.instruments (
+    share_name TEXT PRIMARY KEY,
+    tick_size NUMERIC NOT NULL,
+    min_lot NUMERIC NOT NULL,
+    quantity_scale INTEGER NOT NULL,
+    currency TEXT NOT NULL DEFAULT '',
+    min_order_value NUMERIC NOT NULL DEFAULT 0,
+    max_order_value NUMERIC NOT NULL DEFAULT 0,
+    tradable BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
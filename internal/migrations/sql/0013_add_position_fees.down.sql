@@ -0,0 +1,3 @@
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS close_fee,
+    DROP COLUMN IF EXISTS open_fee;
@@ -0,0 +1,3 @@
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS open_fee NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS close_fee NUMERIC NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS trading.risk_limits (
+    profile_id UUID PRIMARY KEY,
+    max_open_positions INTEGER NOT NULL DEFAULT 0,
+    max_instrument_notional NUMERIC NOT NULL DEFAULT 0,
+    max_total_exposure NUMERIC NOT NULL DEFAULT 0,
+    max_daily_loss NUMERIC NOT NULL DEFAULT 0,
+    max_order_size NUMERIC NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL
+);
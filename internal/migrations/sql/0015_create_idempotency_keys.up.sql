@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS trading.idempotency_keys (
+    profile_id UUID NOT NULL,
+    key TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status TEXT NOT NULL,
+    response JSONB,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (profile_id, key, operation)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON trading.idempotency_keys (expires_at);
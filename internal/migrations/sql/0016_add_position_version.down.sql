@@ -0,0 +1,4 @@
+UPDATE trading.trading SET status = 'open' WHERE status = 'closing';
+
+ALTER TABLE trading.trading
+    DROP COLUMN IF EXISTS version;
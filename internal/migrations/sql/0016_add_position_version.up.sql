@@ -0,0 +1,2 @@
+ALTER TABLE trading.trading
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS trading.balance_operations_applying_idx;
+
+ALTER TABLE trading.balance_operations
+    DROP COLUMN IF EXISTS balance_before,
+    DROP COLUMN IF EXISTS balance_after,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,6 @@
+ALTER TABLE trading.balance_operations
+    ADD COLUMN IF NOT EXISTS balance_before NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS balance_after NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS balance_operations_applying_idx ON trading.balance_operations (profile_id) WHERE status = 'applying';
@@ -0,0 +1 @@
+ALTER TABLE trading.idempotency_keys DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1 @@
+ALTER TABLE trading.idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -1,11 +1,45 @@
 // Package model provides data Structures
 package model
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInsufficientFunds is returned when available balance is less than requested amount
+var ErrInsufficientFunds = errors.New("not enough money on balance")
 
 // Balance struct represents the current balance
 type Balance struct {
-	BalanceID uuid.UUID `json:"balance_id"`
-	ProfileID uuid.UUID `json:"profile_id"`
-	Balance   float64   `json:"balance"`
+	BalanceID uuid.UUID       `json:"balance_id"`
+	ProfileID uuid.UUID       `json:"profile_id"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+// BalanceOperationStatus represents a status of balance operation
+type BalanceOperationStatus string
+
+// Statuses of balance operations
+const (
+	BalanceOperationReserved  BalanceOperationStatus = "reserved"
+	BalanceOperationApplying  BalanceOperationStatus = "applying"
+	BalanceOperationCommitted BalanceOperationStatus = "committed"
+	BalanceOperationReleased  BalanceOperationStatus = "released"
+)
+
+// BalanceOperation struct represents a reservation or a credit of money identified by idempotency key
+// Positive amount is a credit, negative amount is a debit
+// Applying operation is being written to balance service, BalanceBefore and BalanceAfter are the balances
+// it was applied to and it results in, so it is known whether balance service got it
+type BalanceOperation struct {
+	Key           uuid.UUID              `json:"key"`
+	ProfileID     uuid.UUID              `json:"profile_id"`
+	Amount        decimal.Decimal        `json:"amount"`
+	Status        BalanceOperationStatus `json:"status"`
+	BalanceBefore decimal.Decimal        `json:"balance_before"`
+	BalanceAfter  decimal.Decimal        `json:"balance_after"`
+	UpdatedAt     time.Time              `json:"updated_at"`
 }
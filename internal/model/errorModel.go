@@ -0,0 +1,45 @@
+// Package model provides data Structures
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidArgument is returned when a request has invalid fields
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrPriceUnavailable is returned when current price of a share can't be received from price service
+var ErrPriceUnavailable = errors.New("share price is unavailable")
+
+// FieldViolation struct represents an invalid field of a request
+// Field is named as in proto messages, so clients can match it with their input
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// ValidationError struct represents a rejection of a request because of its invalid fields
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+// NewValidationError creates a ValidationError of one invalid field with formatted description
+func NewValidationError(field, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Violations: []FieldViolation{{Field: field, Description: fmt.Sprintf(format, args...)}}}
+}
+
+// Error method joins descriptions of all the violations
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.Field+": "+violation.Description)
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidArgument, strings.Join(descriptions, "; "))
+}
+
+// Unwrap method makes ValidationError match ErrInvalidArgument
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidArgument
+}
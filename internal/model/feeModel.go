@@ -0,0 +1,125 @@
+// Package model provides data Structures
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// FeeTier struct represents a commission rate in percents of notional applied
+// when monthly trading volume of a profile reaches MinVolume
+type FeeTier struct {
+	MinVolume decimal.Decimal `json:"min_volume"`
+	Rate      decimal.Decimal `json:"rate"`
+}
+
+// FeeSchedule struct represents a commission of one trade: Flat amount plus Rate percents of its notional
+// If Tiers are set, rate of the highest tier reached by monthly volume is used instead of Rate
+type FeeSchedule struct {
+	Flat  decimal.Decimal `json:"flat"`
+	Rate  decimal.Decimal `json:"rate"`
+	Tiers []FeeTier       `json:"tiers"`
+}
+
+// IsTiered method reports if commission depends on monthly trading volume
+func (f FeeSchedule) IsTiered() bool {
+	return len(f.Tiers) > 0
+}
+
+// Fee method calculates commission of a trade of given notional for given monthly trading volume
+func (f FeeSchedule) Fee(notional, monthlyVolume decimal.Decimal) decimal.Decimal {
+	rate := f.Rate
+	for _, tier := range f.Tiers {
+		if monthlyVolume.LessThan(tier.MinVolume) {
+			break
+		}
+		rate = tier.Rate
+	}
+	return f.Flat.Add(notional.Mul(rate).Div(decimal.NewFromInt(100)))
+}
+
+// CommissionRules struct represents a default commission schedule and its overrides for particular shares
+type CommissionRules struct {
+	Default     FeeSchedule            `json:"default"`
+	Instruments map[string]FeeSchedule `json:"instruments"`
+}
+
+// Schedule method returns commission schedule of given share
+func (c *CommissionRules) Schedule(shareName string) FeeSchedule {
+	if schedule, ok := c.Instruments[shareName]; ok {
+		return schedule
+	}
+	return c.Default
+}
+
+// ParseFeeTiers function parses tiers in "minVolume:rate,..." format and sorts them by volume
+func ParseFeeTiers(value string) ([]FeeTier, error) {
+	var tiers []FeeTier
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("tier %q must be in minVolume:rate format", item)
+		}
+		minVolume, err := decimal.NewFromString(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("NewFromString: %w", err)
+		}
+		rate, err := decimal.NewFromString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("NewFromString: %w", err)
+		}
+		if minVolume.IsNegative() || rate.IsNegative() {
+			return nil, fmt.Errorf("tier %q can't be negative", item)
+		}
+		tiers = append(tiers, FeeTier{MinVolume: minVolume, Rate: rate})
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinVolume.LessThan(tiers[j].MinVolume)
+	})
+	return tiers, nil
+}
+
+// ParseFeeOverrides function parses commission schedules of shares in "SHARE:flat:rate,..." format
+func ParseFeeOverrides(value string) (map[string]FeeSchedule, error) {
+	overrides := make(map[string]FeeSchedule)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("override %q must be in SHARE:flat:rate format", item)
+		}
+		flat, err := decimal.NewFromString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("NewFromString: %w", err)
+		}
+		rate, err := decimal.NewFromString(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("NewFromString: %w", err)
+		}
+		if flat.IsNegative() || rate.IsNegative() {
+			return nil, fmt.Errorf("override %q can't be negative", item)
+		}
+		overrides[parts[0]] = FeeSchedule{Flat: flat, Rate: rate}
+	}
+	return overrides, nil
+}
+
+// ProfitAndLoss struct represents realized result of a closed trade before and after commissions
+// Gross percents are relative to margin of the trade, net percents to margin plus commission of opening
+type ProfitAndLoss struct {
+	Gross        decimal.Decimal `json:"gross"`
+	Net          decimal.Decimal `json:"net"`
+	GrossPercent decimal.Decimal `json:"gross_percent"`
+	NetPercent   decimal.Decimal `json:"net_percent"`
+	Commission   decimal.Decimal `json:"commission"`
+}
@@ -0,0 +1,96 @@
+// Package model provides data Structures
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFeeScheduleFee(t *testing.T) {
+	tiers := []FeeTier{
+		{MinVolume: decimal.RequireFromString("0"), Rate: decimal.RequireFromString("0.2")},
+		{MinVolume: decimal.RequireFromString("10000"), Rate: decimal.RequireFromString("0.1")},
+		{MinVolume: decimal.RequireFromString("100000"), Rate: decimal.RequireFromString("0.05")},
+	}
+	tests := []struct {
+		name          string
+		schedule      FeeSchedule
+		monthlyVolume string
+		want          string
+	}{
+		{name: "flat and rate", schedule: FeeSchedule{Flat: decimal.RequireFromString("1"), Rate: decimal.RequireFromString("0.1")}, monthlyVolume: "0", want: "2"},
+		{name: "no commission", schedule: FeeSchedule{}, monthlyVolume: "0", want: "0"},
+		{name: "first tier", schedule: FeeSchedule{Flat: decimal.RequireFromString("1"), Tiers: tiers}, monthlyVolume: "9999.99", want: "3"},
+		{name: "reached tier", schedule: FeeSchedule{Flat: decimal.RequireFromString("1"), Tiers: tiers}, monthlyVolume: "10000", want: "2"},
+		{name: "highest tier", schedule: FeeSchedule{Flat: decimal.RequireFromString("1"), Tiers: tiers}, monthlyVolume: "500000", want: "1.5"},
+		{
+			name: "below all tiers",
+			schedule: FeeSchedule{
+				Rate:  decimal.RequireFromString("0.1"),
+				Tiers: []FeeTier{{MinVolume: decimal.RequireFromString("5000"), Rate: decimal.RequireFromString("0.05")}},
+			},
+			monthlyVolume: "100",
+			want:          "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee := tt.schedule.Fee(decimal.RequireFromString("1000"), decimal.RequireFromString(tt.monthlyVolume))
+			if !fee.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("fee of 1000 by volume %v is %v, want %v", tt.monthlyVolume, fee, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFeeTiers(t *testing.T) {
+	tiers, err := ParseFeeTiers("100000:0.05, 0:0.2,10000:0.1,")
+	if err != nil {
+		t.Fatalf("ParseFeeTiers: %v", err)
+	}
+	want := []string{"0", "10000", "100000"}
+	if len(tiers) != len(want) {
+		t.Fatalf("%d tiers are parsed, want %d", len(tiers), len(want))
+	}
+	for i, tier := range tiers {
+		if !tier.MinVolume.Equal(decimal.RequireFromString(want[i])) {
+			t.Errorf("tier %d starts at %v, want %v", i, tier.MinVolume, want[i])
+		}
+	}
+
+	tiers, err = ParseFeeTiers("")
+	if err != nil || len(tiers) != 0 {
+		t.Errorf("ParseFeeTiers of empty value = (%v, %v), want no tiers", tiers, err)
+	}
+
+	for _, value := range []string{"1:2:3", "10000", "a:0.1", "10000:b", "-1:0.1", "10000:-0.1"} {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseFeeTiers(value); err == nil {
+				t.Errorf("ParseFeeTiers(%q) returned no error", value)
+			}
+		})
+	}
+}
+
+func TestParseFeeOverrides(t *testing.T) {
+	overrides, err := ParseFeeOverrides("AAPL:0.5:0.1, TSLA:0:0.2")
+	if err != nil {
+		t.Fatalf("ParseFeeOverrides: %v", err)
+	}
+	if len(overrides) != 2 {
+		t.Fatalf("%d overrides are parsed, want 2", len(overrides))
+	}
+	apple := overrides["AAPL"]
+	if !apple.Flat.Equal(decimal.RequireFromString("0.5")) || !apple.Rate.Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("AAPL schedule is %v + %v%%, want 0.5 + 0.1%%", apple.Flat, apple.Rate)
+	}
+
+	for _, value := range []string{":1:1", "AAPL:1", "AAPL:a:1", "AAPL:1:b", "AAPL:-1:1"} {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseFeeOverrides(value); err == nil {
+				t.Errorf("ParseFeeOverrides(%q) returned no error", value)
+			}
+		})
+	}
+}
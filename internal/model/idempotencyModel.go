@@ -0,0 +1,52 @@
+// Package model provides data Structures
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrIdempotencyKeyInProgress is returned when a request with the same idempotency key is still being processed
+var ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+
+// ErrIdempotencyKeyReused is returned when idempotency key was already used by a different request
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used by a different request")
+
+// ErrIdempotencyKeyNotFound is returned when idempotency key is not present in database
+var ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+// IdempotencyOperation represents an operation protected by idempotency keys, keys of different operations and profiles don't collide
+type IdempotencyOperation string
+
+// Operations protected by idempotency keys
+const (
+	IdempotencyOpenPosition  IdempotencyOperation = "open_position"
+	IdempotencyClosePosition IdempotencyOperation = "close_position"
+)
+
+// IdempotencyStatus represents a status of a request made with idempotency key
+type IdempotencyStatus string
+
+// Statuses of requests made with idempotency key
+const (
+	IdempotencyStatusPending   IdempotencyStatus = "pending"
+	IdempotencyStatusCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord struct represents a request made with idempotency key and its stored result
+// RequestHash tells replays of the request from different requests with the same key
+// Record is replayed until ExpiresAt, after that the key can be used again
+// Pending record is owned by the request until LockedUntil, after that it is reclaimed by a retry of the same request
+type IdempotencyRecord struct {
+	ProfileID   uuid.UUID            `json:"profile_id"`
+	Key         string               `json:"key"`
+	Operation   IdempotencyOperation `json:"operation"`
+	RequestHash string               `json:"request_hash"`
+	Status      IdempotencyStatus    `json:"status"`
+	Response    []byte               `json:"response"`
+	CreatedAt   time.Time            `json:"created_at"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+	LockedUntil time.Time            `json:"locked_until"`
+}
@@ -0,0 +1,50 @@
+// Package model provides data Structures
+package model
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInstrumentNotFound is returned when share is not present in instrument registry
+var ErrInstrumentNotFound = errors.New("instrument not found")
+
+// ErrInstrumentNotTradable is returned when share of the instrument can't be traded now
+var ErrInstrumentNotTradable = errors.New("instrument is not tradable")
+
+// Instrument struct represents a tradable share and its trading rules
+// Prices are multiples of TickSize, amounts of shares have QuantityScale decimal places and are not less than MinLot
+// Order value is a value of shares bought by the order, zero MaxOrderValue means that order value is not limited
+type Instrument struct {
+	ShareName     string          `json:"share_name"`
+	TickSize      decimal.Decimal `json:"tick_size"`
+	MinLot        decimal.Decimal `json:"min_lot"`
+	QuantityScale int32           `json:"quantity_scale"`
+	Currency      string          `json:"currency"`
+	MinOrderValue decimal.Decimal `json:"min_order_value"`
+	MaxOrderValue decimal.Decimal `json:"max_order_value"`
+	Tradable      bool            `json:"tradable"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// Precision method returns precision of prices and amounts of shares of the instrument
+func (i *Instrument) Precision() Precision {
+	return Precision{TickSize: i.TickSize, AmountScale: i.QuantityScale}
+}
+
+// InstrumentRegistry struct represents in-memory cache of instruments by share name
+type InstrumentRegistry struct {
+	Mu          sync.RWMutex
+	Instruments map[string]*Instrument
+}
+
+// NewInstrumentRegistry creates a new instrument registry
+func NewInstrumentRegistry() *InstrumentRegistry {
+	return &InstrumentRegistry{
+		Instruments: make(map[string]*Instrument),
+	}
+}
@@ -0,0 +1,28 @@
+// Package model provides data Structures
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MarginRules struct represents limits of leverage and margin levels of leveraged trading
+// Margin levels are percents of equity to maintenance margin
+type MarginRules struct {
+	MaxLeverage           decimal.Decimal `json:"max_leverage"`
+	MaintenanceMarginRate decimal.Decimal `json:"maintenance_margin_rate"`
+	MarginCallLevel       decimal.Decimal `json:"margin_call_level"`
+	LiquidationLevel      decimal.Decimal `json:"liquidation_level"`
+}
+
+// MarginLevel struct represents margin state of a profile
+// Equity is a balance plus margins and unrealized PnL of opened positions, maintenance margin is required only for leveraged positions
+type MarginLevel struct {
+	ProfileID         uuid.UUID       `json:"profile_id"`
+	Balance           decimal.Decimal `json:"balance"`
+	Equity            decimal.Decimal `json:"equity"`
+	UsedMargin        decimal.Decimal `json:"used_margin"`
+	MaintenanceMargin decimal.Decimal `json:"maintenance_margin"`
+	Level             decimal.Decimal `json:"level"`
+	MarginCall        bool            `json:"margin_call"`
+}
@@ -0,0 +1,113 @@
+// Package model provides data Structures
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrOrderNotFound is returned when order is not present in database or it is not in expected status
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrOrderNotActive is returned when order can't be changed because it is already filled, canceled, rejected or expired
+var ErrOrderNotActive = errors.New("order is not active")
+
+// ErrOrderConflict is returned when order is being filled by matcher at the moment
+var ErrOrderConflict = errors.New("order is being filled")
+
+// OrderType represents a type of pending entry order
+type OrderType string
+
+// Types of pending entry orders
+const (
+	OrderBuyLimit  OrderType = "buy_limit"
+	OrderSellLimit OrderType = "sell_limit"
+	OrderBuyStop   OrderType = "buy_stop"
+	OrderSellStop  OrderType = "sell_stop"
+)
+
+// TimeInForce represents a policy of how long an order stays pending
+type TimeInForce string
+
+// Time-in-force policies, GTD and DAY orders expire at ExpiresAt, IOC and FOK orders are matched only once when placed
+// Orders are never filled partially, so FOK order is either filled whole right away or expires the same way as IOC order
+const (
+	TimeInForceGTC TimeInForce = "gtc"
+	TimeInForceGTD TimeInForce = "gtd"
+	TimeInForceDAY TimeInForce = "day"
+	TimeInForceIOC TimeInForce = "ioc"
+	TimeInForceFOK TimeInForce = "fok"
+)
+
+// OrderStatus represents a status of pending entry order
+type OrderStatus string
+
+// Statuses of pending entry orders
+const (
+	OrderStatusInactive OrderStatus = "inactive"
+	OrderStatusPending  OrderStatus = "pending"
+	OrderStatusFilled   OrderStatus = "filled"
+	OrderStatusCanceled OrderStatus = "canceled"
+	OrderStatusRejected OrderStatus = "rejected"
+	OrderStatusExpired  OrderStatus = "expired"
+)
+
+// Order struct represents an entry order which opens a position when share price reaches its trigger price
+// Exit order of a bracket has ParentID of its entry order and closes the position of the entry order
+// Exit orders are inactive until the entry order is filled, filling of one exit order cancels the other one
+type Order struct {
+	ID           uuid.UUID       `json:"id"`
+	ProfileID    uuid.UUID       `json:"profile_id"`
+	ShareName    string          `json:"share_name"`
+	Type         OrderType       `json:"type"`
+	TriggerPrice decimal.Decimal `json:"trigger_price"`
+	Total        decimal.Decimal `json:"total"`
+	StopLoss     decimal.Decimal `json:"stop_loss"`
+	TakeProfit   decimal.Decimal `json:"take_profit"`
+	Status       OrderStatus     `json:"status"`
+	PositionID   uuid.UUID       `json:"position_id"`
+	ParentID     *uuid.UUID      `json:"parent_id"`
+	TimeInForce  TimeInForce     `json:"time_in_force"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+	Error        string          `json:"error"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// IsLong method returns true if the order opens a long position
+func (o *Order) IsLong() bool {
+	return o.Type == OrderBuyLimit || o.Type == OrderBuyStop
+}
+
+// IsExit method returns true if the order closes a position of its entry order
+func (o *Order) IsExit() bool {
+	return o.ParentID != nil
+}
+
+// IsImmediate method returns true if the order is canceled when it can't be filled right after placing
+func (o *Order) IsImmediate() bool {
+	return o.TimeInForce == TimeInForceIOC || o.TimeInForce == TimeInForceFOK
+}
+
+// IsExpired method returns true if the order has expiry time which is not after given time
+func (o *Order) IsExpired(now time.Time) bool {
+	return o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
+// OrderAmendment struct represents new terms of the order which is not triggered yet, zero fields are left unchanged
+type OrderAmendment struct {
+	TriggerPrice decimal.Decimal `json:"trigger_price"`
+	TimeInForce  TimeInForce     `json:"time_in_force"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+}
+
+// OrderFilter struct represents filter and pagination parameters of orders list
+type OrderFilter struct {
+	ProfileID uuid.UUID   `json:"profile_id"`
+	Status    OrderStatus `json:"status"`
+	Limit     int         `json:"limit"`
+	Cursor    uuid.UUID   `json:"cursor"`
+}
@@ -0,0 +1,39 @@
+// Package model provides data Structures
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// PercentScale is a number of decimal places of percents
+const PercentScale = 2
+
+// Precision struct represents a tick size of share prices and a number of decimal places of amounts of shares
+type Precision struct {
+	TickSize    decimal.Decimal `json:"tick_size"`
+	AmountScale int32           `json:"amount_scale"`
+}
+
+// Price method rounds share price to the nearest multiple of tick size
+func (p Precision) Price(price decimal.Decimal) decimal.Decimal {
+	if !p.TickSize.IsPositive() {
+		return price
+	}
+	return price.Div(p.TickSize).Round(0).Mul(p.TickSize)
+}
+
+// Amount method truncates amount of shares, so shares never cost more than the money paid for them
+func (p Precision) Amount(shareAmount decimal.Decimal) decimal.Decimal {
+	return shareAmount.Truncate(p.AmountScale)
+}
+
+// RoundingRules struct represents rounding of money and precision of shares which are not in instrument registry
+type RoundingRules struct {
+	MoneyScale int32     `json:"money_scale"`
+	Default    Precision `json:"default"`
+}
+
+// Money method rounds amount of money half away from zero
+func (r *RoundingRules) Money(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(r.MoneyScale)
+}
@@ -0,0 +1,53 @@
+// Package model provides data Structures
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestPrecisionPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickSize string
+		price    string
+		want     string
+	}{
+		{name: "multiple of tick size", tickSize: "0.01", price: "10.25", want: "10.25"},
+		{name: "rounded down", tickSize: "0.05", price: "10.02", want: "10"},
+		{name: "rounded up", tickSize: "0.05", price: "10.03", want: "10.05"},
+		{name: "half is rounded away from zero", tickSize: "0.5", price: "10.25", want: "10.5"},
+		{name: "whole tick", tickSize: "5", price: "12", want: "10"},
+		{name: "tick size is not set", tickSize: "0", price: "10.123456", want: "10.123456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precision := Precision{TickSize: decimal.RequireFromString(tt.tickSize)}
+			if got := precision.Price(decimal.RequireFromString(tt.price)); !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Price(%v) = %v, want %v", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrecisionAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		amountScale int32
+		shareAmount string
+		want        string
+	}{
+		{name: "whole shares", amountScale: 0, shareAmount: "3.99", want: "3"},
+		{name: "fractional shares are truncated", amountScale: 2, shareAmount: "3.999", want: "3.99"},
+		{name: "exact amount", amountScale: 4, shareAmount: "0.125", want: "0.125"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precision := Precision{AmountScale: tt.amountScale}
+			if got := precision.Amount(decimal.RequireFromString(tt.shareAmount)); !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Amount(%v) = %v, want %v", tt.shareAmount, got, tt.want)
+			}
+		})
+	}
+}
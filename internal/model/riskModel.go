@@ -0,0 +1,83 @@
+// Package model provides data Structures
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrRiskLimitExceeded is returned when a trade violates risk limits of its profile
+var ErrRiskLimitExceeded = errors.New("risk limit exceeded")
+
+// ErrRiskLimitsNotFound is returned when profile has no own risk limits and default ones apply
+var ErrRiskLimitsNotFound = errors.New("risk limits not found")
+
+// RiskLimits struct represents pre-trade risk limits of a profile, zero limit means that it is not limited
+// Notional and exposure are values of shares by their open prices, daily loss is a net realized loss since the day start
+type RiskLimits struct {
+	ProfileID             uuid.UUID       `json:"profile_id"`
+	MaxOpenPositions      int             `json:"max_open_positions"`
+	MaxInstrumentNotional decimal.Decimal `json:"max_instrument_notional"`
+	MaxTotalExposure      decimal.Decimal `json:"max_total_exposure"`
+	MaxDailyLoss          decimal.Decimal `json:"max_daily_loss"`
+	MaxOrderSize          decimal.Decimal `json:"max_order_size"`
+	UpdatedAt             time.Time       `json:"updated_at"`
+}
+
+// RiskRule represents a risk limit checked before a trade
+type RiskRule string
+
+// Risk limits checked before a trade
+const (
+	RiskRuleMaxOpenPositions      RiskRule = "MAX_OPEN_POSITIONS"
+	RiskRuleMaxInstrumentNotional RiskRule = "MAX_INSTRUMENT_NOTIONAL"
+	RiskRuleMaxTotalExposure      RiskRule = "MAX_TOTAL_EXPOSURE"
+	RiskRuleMaxDailyLoss          RiskRule = "MAX_DAILY_LOSS"
+	RiskRuleMaxOrderSize          RiskRule = "MAX_ORDER_SIZE"
+)
+
+// RiskViolation struct represents a risk limit which a trade would break
+// Value is what the limited quantity would become after the trade
+type RiskViolation struct {
+	Rule    RiskRule        `json:"rule"`
+	Subject string          `json:"subject"`
+	Limit   decimal.Decimal `json:"limit"`
+	Value   decimal.Decimal `json:"value"`
+}
+
+// Description method returns human-readable description of the violation
+func (v RiskViolation) Description() string {
+	return fmt.Sprintf("%s of %s would be %v, limit is %v", v.Rule, v.Subject, v.Value, v.Limit)
+}
+
+// RiskLimitError struct represents a rejection of a trade by pre-trade risk check with all the violated limits
+type RiskLimitError struct {
+	Violations []RiskViolation
+}
+
+// Error method joins descriptions of all the violations
+func (e *RiskLimitError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.Description())
+	}
+	return fmt.Sprintf("%v: %s", ErrRiskLimitExceeded, strings.Join(descriptions, "; "))
+}
+
+// Unwrap method makes RiskLimitError match ErrRiskLimitExceeded
+func (e *RiskLimitError) Unwrap() error {
+	return ErrRiskLimitExceeded
+}
+
+// RiskExposure struct represents current exposure of a profile which is checked against its risk limits
+type RiskExposure struct {
+	OpenPositions      int             `json:"open_positions"`
+	InstrumentNotional decimal.Decimal `json:"instrument_notional"`
+	TotalExposure      decimal.Decimal `json:"total_exposure"`
+	DailyLoss          decimal.Decimal `json:"daily_loss"`
+}
@@ -0,0 +1,21 @@
+// Package model provides data Structures
+package model
+
+import "time"
+
+// TradingSchedule struct represents a daily market close used to expire DAY orders
+// MarketClose is an offset of the close from midnight in Location
+type TradingSchedule struct {
+	MarketClose time.Duration
+	Location    *time.Location
+}
+
+// NextMarketClose method returns the first market close after given time
+func (s *TradingSchedule) NextMarketClose(now time.Time) time.Time {
+	local := now.In(s.Location)
+	marketClose := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.Location).Add(s.MarketClose)
+	if !marketClose.After(local) {
+		marketClose = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, s.Location).Add(s.MarketClose)
+	}
+	return marketClose
+}
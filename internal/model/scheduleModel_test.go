@@ -0,0 +1,50 @@
+// Package model provides data Structures
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextMarketClose(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*60*60)
+	schedule := &TradingSchedule{MarketClose: 16 * time.Hour, Location: newYork}
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "before close",
+			now:  time.Date(2024, 3, 4, 10, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 4, 16, 0, 0, 0, newYork),
+		},
+		{
+			name: "at close",
+			now:  time.Date(2024, 3, 4, 16, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 5, 16, 0, 0, 0, newYork),
+		},
+		{
+			name: "after close",
+			now:  time.Date(2024, 3, 4, 20, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 5, 16, 0, 0, 0, newYork),
+		},
+		{
+			name: "next day in UTC but the same day in location",
+			now:  time.Date(2024, 3, 5, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 5, 16, 0, 0, 0, newYork),
+		},
+		{
+			name: "after close of the last day of month",
+			now:  time.Date(2024, 2, 29, 23, 30, 0, 0, newYork),
+			want: time.Date(2024, 3, 1, 16, 0, 0, 0, newYork),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.NextMarketClose(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextMarketClose(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
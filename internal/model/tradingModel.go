@@ -2,43 +2,145 @@
 package model
 
 import (
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrPositionNotFound is returned when position is not present in database
+var ErrPositionNotFound = errors.New("position not found")
+
+// ErrPositionNotOpen is returned when position can't be changed because it is already closed
+var ErrPositionNotOpen = errors.New("position is not open")
+
+// ErrPositionConflict is returned when position was changed or is being closed by another request since it was read
+var ErrPositionConflict = errors.New("position was changed concurrently")
+
+// PositionStatus represents a status of a position
+type PositionStatus string
+
+// Statuses of positions, position moves from open to closed through closing only once
+// Closing position returns to open if its closing fails before it is closed in database
+const (
+	PositionStatusOpen    PositionStatus = "open"
+	PositionStatusClosing PositionStatus = "closing"
+	PositionStatusClosed  PositionStatus = "closed"
+)
+
+// TrailingStopType represents a mode of trailing stop loss
+type TrailingStopType string
+
+// Modes of trailing stop loss, empty mode means that stop loss is fixed
+const (
+	TrailingStopNone    TrailingStopType = ""
+	TrailingStopAmount  TrailingStopType = "amount"
+	TrailingStopPercent TrailingStopType = "percent"
 )
 
 // Position struct represents an user's position
+// If trailing stop is set, StopLoss is its current level which follows the price
+// Closed part of partially closed position is stored as a separate closed position with ParentID of the original one
+// Total is a margin of the position, shares are bought for Total multiplied by Leverage
+// Position with ExpiresAt is closed automatically when the time comes
+// RealizedPnL is gross, commissions paid for opening and closing are stored in OpenFee and CloseFee
+// Version is incremented by every change of status, amount of shares or total, a change is stored only if the version was not changed since the position was read
 type Position struct {
-	ID          uuid.UUID `json:"id"`
-	ProfileID   uuid.UUID `json:"profile_id"`
-	IsLong      bool      `json:"is_long"`
-	ShareName   string    `json:"share_name"`
-	SharePrice  float64   `json:"share_price"`
-	Total       float64   `json:"total"`
-	ShareAmount float64   `json:"share_amount"`
-	StopLoss    float64   `json:"stop_loss"`
-	TakeProfit  float64   `json:"take_profit"`
+	ID                   uuid.UUID        `json:"id"`
+	ProfileID            uuid.UUID        `json:"profile_id"`
+	IsLong               bool             `json:"is_long"`
+	ShareName            string           `json:"share_name"`
+	SharePrice           decimal.Decimal  `json:"share_price"`
+	Total                decimal.Decimal  `json:"total"`
+	ShareAmount          decimal.Decimal  `json:"share_amount"`
+	StopLoss             decimal.Decimal  `json:"stop_loss"`
+	TakeProfit           decimal.Decimal  `json:"take_profit"`
+	Status               PositionStatus   `json:"status"`
+	TrailingStopType     TrailingStopType `json:"trailing_stop_type"`
+	TrailingStopDistance decimal.Decimal  `json:"trailing_stop_distance"`
+	ExitPrice            decimal.Decimal  `json:"exit_price"`
+	RealizedPnL          decimal.Decimal  `json:"realized_pnl"`
+	CloseReason          CloseReason      `json:"close_reason"`
+	OpenedAt             time.Time        `json:"opened_at"`
+	ClosedAt             *time.Time       `json:"closed_at"`
+	ParentID             *uuid.UUID       `json:"parent_id"`
+	Leverage             decimal.Decimal  `json:"leverage"`
+	ExpiresAt            *time.Time       `json:"expires_at"`
+	OpenFee              decimal.Decimal  `json:"open_fee"`
+	CloseFee             decimal.Decimal  `json:"close_fee"`
+	Version              int64            `json:"version"`
+}
+
+// NetPnL method returns realized PnL of the position after commissions of its opening and closing
+func (p *Position) NetPnL() decimal.Decimal {
+	return p.RealizedPnL.Sub(p.OpenFee).Sub(p.CloseFee)
+}
+
+// ClosePart struct represents a part of position to close by amount of shares or by percentage
+// Zero part means the whole position
+type ClosePart struct {
+	ShareAmount decimal.Decimal `json:"share_amount"`
+	Percent     decimal.Decimal `json:"percent"`
 }
 
+// OpenedPosition struct represents an opened position watched by position manager
 type OpenedPosition struct {
-	PositionID      uuid.UUID `json:"position_id"`
-	ShareOpenPrice  float64   `json:"share_open_price"`
-	ShareClosePrice float64   `json:"share_close_price"`
-	ShareAmount     float64   `json:"share_amount"`
-	IsOpened        bool      `json:"is_closed"`
+	PositionID           uuid.UUID        `json:"position_id"`
+	ProfileID            uuid.UUID        `json:"profile_id"`
+	ShareName            string           `json:"share_name"`
+	IsLong               bool             `json:"is_long"`
+	ShareOpenPrice       decimal.Decimal  `json:"share_open_price"`
+	ShareClosePrice      decimal.Decimal  `json:"share_close_price"`
+	TakeProfit           decimal.Decimal  `json:"take_profit"`
+	ShareAmount          decimal.Decimal  `json:"share_amount"`
+	IsOpened             bool             `json:"is_closed"`
+	TrailingStopType     TrailingStopType `json:"trailing_stop_type"`
+	TrailingStopDistance decimal.Decimal  `json:"trailing_stop_distance"`
+	Margin               decimal.Decimal  `json:"margin"`
+	Leverage             decimal.Decimal  `json:"leverage"`
+	ExpiresAt            *time.Time       `json:"expires_at"`
+}
+
+// CloseReason represents the reason why position was closed
+type CloseReason string
+
+// Close reasons of a position
+const (
+	CloseReasonManual      CloseReason = "manual"
+	CloseReasonStopLoss    CloseReason = "stop_loss"
+	CloseReasonTakeProfit  CloseReason = "take_profit"
+	CloseReasonLiquidation CloseReason = "liquidation"
+	CloseReasonExpired     CloseReason = "expired"
+)
+
+// TriggerEvent struct represents an automatic close of position by stop loss or take profit
+type TriggerEvent struct {
+	PositionID     uuid.UUID       `json:"position_id"`
+	ProfileID      uuid.UUID       `json:"profile_id"`
+	ShareName      string          `json:"share_name"`
+	Reason         CloseReason     `json:"reason"`
+	TriggerPrice   decimal.Decimal `json:"trigger_price"`
+	ExecutionPrice decimal.Decimal `json:"execution_price"`
+	PnL            decimal.Decimal `json:"pnl"`
+	TriggeredAt    time.Time       `json:"triggered_at"`
 }
 
 // Share struct represents one share
 type Share struct {
-	ShareName  string  `json:"share_name"`
-	SharePrice float64 `json:"share_price"`
+	ShareName  string          `json:"share_name"`
+	SharePrice decimal.Decimal `json:"share_price"`
 }
 
-// PubSub struct represents a model for subscriptions(subscriber part)
+// PositionManager struct represents in-memory state of opened positions and pending orders
 type PositionManager struct {
 	Mu              sync.RWMutex
 	OpenedPositions map[uuid.UUID]map[uuid.UUID]*OpenedPosition
 	Closed          map[uuid.UUID]bool
+	Subscribers     map[uuid.UUID]map[uuid.UUID]chan *PositionUpdate
+	PendingOrders   map[uuid.UUID]*Order
+	MarginCalls     map[uuid.UUID]bool
 }
 
 // NewPositionManager creates a new position manager
@@ -46,5 +148,164 @@ func NewPositionManager() *PositionManager {
 	return &PositionManager{
 		OpenedPositions: make(map[uuid.UUID]map[uuid.UUID]*OpenedPosition),
 		Closed:          make(map[uuid.UUID]bool),
+		Subscribers:     make(map[uuid.UUID]map[uuid.UUID]chan *PositionUpdate),
+		PendingOrders:   make(map[uuid.UUID]*Order),
+		MarginCalls:     make(map[uuid.UUID]bool),
 	}
 }
+
+// PositionDetails struct represents a position with its current market state
+// Closed position is marked by its exit price and has no unrealized PnL, its result is in RealizedPnL of the position
+type PositionDetails struct {
+	Position             *Position       `json:"position"`
+	MarkPrice            decimal.Decimal `json:"mark_price"`
+	UnrealizedPnL        decimal.Decimal `json:"unrealized_pnl"`
+	UnrealizedPnLPercent decimal.Decimal `json:"unrealized_pnl_percent"`
+}
+
+// ClosedTradeFilter struct represents filter and pagination parameters of closed trades list
+type ClosedTradeFilter struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Limit     int       `json:"limit"`
+	Cursor    uuid.UUID `json:"cursor"`
+}
+
+// PositionFilter struct represents filter and pagination parameters of positions list
+type PositionFilter struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	ShareName string    `json:"share_name"`
+	IsLong    *bool     `json:"is_long"`
+	Limit     int       `json:"limit"`
+	Cursor    uuid.UUID `json:"cursor"`
+}
+
+// PositionUpdateType represents a type of position update
+type PositionUpdateType string
+
+// Types of position updates
+const (
+	PositionUpdatePrice      PositionUpdateType = "price"
+	PositionUpdateOpened     PositionUpdateType = "opened"
+	PositionUpdateClosed     PositionUpdateType = "closed"
+	PositionUpdateReduced    PositionUpdateType = "reduced"
+	PositionUpdateIncreased  PositionUpdateType = "increased"
+	PositionUpdateMarginCall PositionUpdateType = "margin_call"
+)
+
+// PositionUpdate struct represents a live update of user's position
+type PositionUpdate struct {
+	Type                 PositionUpdateType `json:"type"`
+	PositionID           uuid.UUID          `json:"position_id"`
+	ProfileID            uuid.UUID          `json:"profile_id"`
+	ShareName            string             `json:"share_name"`
+	IsLong               bool               `json:"is_long"`
+	SharePrice           decimal.Decimal    `json:"share_price"`
+	MarkPrice            decimal.Decimal    `json:"mark_price"`
+	ShareAmount          decimal.Decimal    `json:"share_amount"`
+	UnrealizedPnL        decimal.Decimal    `json:"unrealized_pnl"`
+	UnrealizedPnLPercent decimal.Decimal    `json:"unrealized_pnl_percent"`
+	StopLoss             decimal.Decimal    `json:"stop_loss"`
+	TakeProfit           decimal.Decimal    `json:"take_profit"`
+	StopLossDistance     decimal.Decimal    `json:"stop_loss_distance"`
+	TakeProfitDistance   decimal.Decimal    `json:"take_profit_distance"`
+	CloseReason          CloseReason        `json:"close_reason"`
+	PnL                  decimal.Decimal    `json:"pnl"`
+	MarginLevel          decimal.Decimal    `json:"margin_level"`
+}
+
+// ReconciliationReport struct represents differences between positions in database and position manager
+type ReconciliationReport struct {
+	Restored          []uuid.UUID `json:"restored"`
+	OnlyInDatabase    []uuid.UUID `json:"only_in_database"`
+	OnlyInManager     []uuid.UUID `json:"only_in_manager"`
+	UnavailableShares []string    `json:"unavailable_shares"`
+}
+
+// SagaType represents a type of distributed operation over balance and positions
+type SagaType string
+
+// Types of sagas
+const (
+	SagaOpenPosition     SagaType = "open_position"
+	SagaClosePosition    SagaType = "close_position"
+	SagaIncreasePosition SagaType = "increase_position"
+)
+
+// SagaStep represents the last completed step of a saga
+type SagaStep string
+
+// Steps of sagas
+const (
+	SagaStepStarted           SagaStep = "started"
+	SagaStepBalanceReserved   SagaStep = "balance_reserved"
+	SagaStepBalanceDebited    SagaStep = "balance_debited"
+	SagaStepPositionCreated   SagaStep = "position_created"
+	SagaStepPositionClosing   SagaStep = "position_closing"
+	SagaStepPositionClosed    SagaStep = "position_closed"
+	SagaStepPositionIncreased SagaStep = "position_increased"
+	SagaStepBalanceCredited   SagaStep = "balance_credited"
+)
+
+// SagaStatus represents a status of a saga
+type SagaStatus string
+
+// Statuses of sagas
+const (
+	SagaStatusPending     SagaStatus = "pending"
+	SagaStatusCompleted   SagaStatus = "completed"
+	SagaStatusCompensated SagaStatus = "compensated"
+	SagaStatusFailed      SagaStatus = "failed"
+)
+
+// Saga struct represents a persisted state of opening or closing of a position
+type Saga struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       SagaType        `json:"type"`
+	Step       SagaStep        `json:"step"`
+	Status     SagaStatus      `json:"status"`
+	Position   *Position       `json:"position"`
+	Amount     decimal.Decimal `json:"amount"`
+	SharePrice decimal.Decimal `json:"share_price"`
+	PnL        decimal.Decimal `json:"pnl"`
+	Reason     CloseReason     `json:"reason"`
+	Error      string          `json:"error"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// OutboxEventType represents a type of position lifecycle event
+type OutboxEventType string
+
+// Types of position lifecycle events
+const (
+	EventPositionOpened    OutboxEventType = "PositionOpened"
+	EventPositionClosed    OutboxEventType = "PositionClosed"
+	EventStopTriggered     OutboxEventType = "StopTriggered"
+	EventPositionIncreased OutboxEventType = "PositionIncreased"
+	EventMarginCall        OutboxEventType = "MarginCall"
+	EventOrderExpired      OutboxEventType = "OrderExpired"
+)
+
+// OutboxEvent struct represents a position lifecycle event stored in outbox until it is published
+// Sequence numbers events of a profile in order of their commit without gaps
+type OutboxEvent struct {
+	ID         int64           `json:"id"`
+	Type       OutboxEventType `json:"type"`
+	ProfileID  uuid.UUID       `json:"profile_id"`
+	PositionID uuid.UUID       `json:"position_id"`
+	Sequence   int64           `json:"sequence"`
+	Payload    []byte          `json:"payload"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ClosedPositionPayload struct represents a payload of PositionClosed event
+type ClosedPositionPayload struct {
+	Position   *Position       `json:"position"`
+	SharePrice decimal.Decimal `json:"share_price"`
+	PnL        decimal.Decimal `json:"pnl"`
+	NetPnL     decimal.Decimal `json:"net_pnl"`
+	Commission decimal.Decimal `json:"commission"`
+	Reason     CloseReason     `json:"reason"`
+}
@@ -3,21 +3,37 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	proto "github.com/eugenshima/balance/proto"
 	"github.com/eugenshima/trading-service/internal/model"
+
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
 )
 
+// balanceUpdateTimeout is a deadline of writing a balance to balance service, operation interrupted by a failure
+// is finished by another call only after this time, so the interrupted call can't land after a newer balance
+const balanceUpdateTimeout = 10 * time.Second
+
 // BalanceRepository represents a repository that contains balance microservice methods
+// Balance service keeps balances as float64, so they are converted to decimals only here
+// Balance service only supports absolute updates, so every change of a profile balance is serialized
+// with postgres advisory lock and recorded in trading.balance_operations by its idempotency key
+// before balance service is called
 type BalanceRepository struct {
 	client proto.BalanceServiceClient
+	pool   *pgxpool.Pool
 }
 
 // NewBalanceRepository creates a new BalanceRepository
-func NewBalanceRepository(client proto.BalanceServiceClient) *BalanceRepository {
-	return &BalanceRepository{client: client}
+func NewBalanceRepository(client proto.BalanceServiceClient, pool *pgxpool.Pool) *BalanceRepository {
+	return &BalanceRepository{client: client, pool: pool}
 }
 
 // GetBalance method returns a balance by given ID
@@ -37,17 +53,18 @@ func (r *BalanceRepository) GetBalance(ctx context.Context, ID uuid.UUID) (*mode
 	balance := &model.Balance{
 		BalanceID: balanceID,
 		ProfileID: profileID,
-		Balance:   response.Balance.Balance,
+		Balance:   decimal.NewFromFloat(response.Balance.Balance),
 	}
 	return balance, nil
 }
 
 // UpdateBalance method updates a balance of given ID
 func (r *BalanceRepository) UpdateBalance(ctx context.Context, balance *model.Balance) error {
+	floatBalance, _ := balance.Balance.Float64()
 	protoBalance := &proto.Balance{
 		BalanceID: balance.BalanceID.String(),
 		ProfileID: balance.ProfileID.String(),
-		Balance:   balance.Balance,
+		Balance:   floatBalance,
 	}
 	_, err := r.client.UpdateUserBalance(ctx, &proto.UserUpdateRequest{Balance: protoBalance})
 	if err != nil {
@@ -55,3 +72,281 @@ func (r *BalanceRepository) UpdateBalance(ctx context.Context, balance *model.Ba
 	}
 	return nil
 }
+
+// Reserve method holds given amount of money on the balance, repeated call with the same key does nothing
+func (r *BalanceRepository) Reserve(ctx context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	return r.withProfileLock(ctx, profileID, func(conn *pgxpool.Conn) error {
+		operation, err := getBalanceOperation(ctx, conn, key)
+		if err != nil {
+			return fmt.Errorf("getBalanceOperation: %w", err)
+		}
+		if operation != nil {
+			return checkSameOperation(operation, profileID, amount.Neg())
+		}
+		balance, err := r.getSettledBalance(ctx, conn, profileID)
+		if err != nil {
+			return fmt.Errorf("getSettledBalance: %w", err)
+		}
+		var reserved decimal.Decimal
+		err = conn.QueryRow(ctx, "SELECT COALESCE(SUM(-amount), 0) FROM trading.balance_operations WHERE profile_id=$1 AND status=$2", profileID, model.BalanceOperationReserved).Scan(&reserved)
+		if err != nil {
+			return fmt.Errorf("QueryRow: %w", err)
+		}
+		available := balance.Balance.Sub(reserved)
+		if available.LessThan(amount) {
+			return fmt.Errorf("available %v, requested %v: %w", available, amount, model.ErrInsufficientFunds)
+		}
+		_, err = conn.Exec(ctx, "INSERT INTO trading.balance_operations (key, profile_id, amount, status, updated_at) VALUES($1,$2,$3,$4,$5)",
+			key, profileID, amount.Neg(), model.BalanceOperationReserved, time.Now())
+		if err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+		return nil
+	})
+}
+
+// Commit method debits reserved money from the balance, repeated call with the same key does nothing
+// Commit interrupted while the balance was being written is finished by the repeated call
+func (r *BalanceRepository) Commit(ctx context.Context, key uuid.UUID) error {
+	operation, err := getBalanceOperation(ctx, r.pool, key)
+	if err != nil {
+		return fmt.Errorf("getBalanceOperation: %w", err)
+	}
+	if operation == nil {
+		return fmt.Errorf("reservation %v not found", key)
+	}
+	return r.withProfileLock(ctx, operation.ProfileID, func(conn *pgxpool.Conn) error {
+		operation, err := getBalanceOperation(ctx, conn, key)
+		if err != nil {
+			return fmt.Errorf("getBalanceOperation: %w", err)
+		}
+		switch operation.Status {
+		case model.BalanceOperationCommitted:
+			return nil
+		case model.BalanceOperationReleased:
+			return fmt.Errorf("reservation %v is already released", key)
+		case model.BalanceOperationApplying:
+			return r.finishInterruptedOperation(ctx, conn, operation)
+		}
+		return r.applyOperation(ctx, conn, operation)
+	})
+}
+
+// Release method cancels the reservation, repeated call or call with unknown key does nothing
+func (r *BalanceRepository) Release(ctx context.Context, key uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, "UPDATE trading.balance_operations SET status=$1, updated_at=$2 WHERE key=$3 AND status=$4",
+		model.BalanceOperationReleased, time.Now(), key, model.BalanceOperationReserved)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		operation, err := getBalanceOperation(ctx, r.pool, key)
+		if err != nil {
+			return fmt.Errorf("getBalanceOperation: %w", err)
+		}
+		if operation != nil && operation.Status != model.BalanceOperationReleased {
+			return fmt.Errorf("reservation %v is already %s", key, operation.Status)
+		}
+	}
+	return nil
+}
+
+// Credit method adds given amount of money to the balance, repeated call with the same key does nothing
+// Credit interrupted while the balance was being written is finished by the repeated call
+func (r *BalanceRepository) Credit(ctx context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	return r.withProfileLock(ctx, profileID, func(conn *pgxpool.Conn) error {
+		operation, err := getBalanceOperation(ctx, conn, key)
+		if err != nil {
+			return fmt.Errorf("getBalanceOperation: %w", err)
+		}
+		if operation != nil {
+			err = checkSameOperation(operation, profileID, amount)
+			if err != nil {
+				return err
+			}
+			if operation.Status == model.BalanceOperationApplying {
+				return r.finishInterruptedOperation(ctx, conn, operation)
+			}
+			return nil
+		}
+		operation = &model.BalanceOperation{Key: key, ProfileID: profileID, Amount: amount, Status: model.BalanceOperationReserved}
+		_, err = conn.Exec(ctx, "INSERT INTO trading.balance_operations (key, profile_id, amount, status, updated_at) VALUES($1,$2,$3,$4,$5)",
+			key, profileID, amount, model.BalanceOperationReserved, time.Now())
+		if err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+		return r.applyOperation(ctx, conn, operation)
+	})
+}
+
+// applyOperation method adds amount of the operation to the balance and marks it as committed
+// Balances before and after the operation are stored before balance service is called, so the operation
+// interrupted by a failure is finished later without being applied twice
+func (r *BalanceRepository) applyOperation(ctx context.Context, conn *pgxpool.Conn, operation *model.BalanceOperation) error {
+	balance, err := r.getSettledBalance(ctx, conn, operation.ProfileID)
+	if err != nil {
+		return fmt.Errorf("getSettledBalance: %w", err)
+	}
+	operation.Status = model.BalanceOperationApplying
+	operation.BalanceBefore = balance.Balance
+	operation.BalanceAfter = balance.Balance.Add(operation.Amount)
+	operation.UpdatedAt = time.Now()
+	_, err = conn.Exec(ctx, "UPDATE trading.balance_operations SET status=$1, balance_before=$2, balance_after=$3, updated_at=$4 WHERE key=$5",
+		operation.Status, operation.BalanceBefore, operation.BalanceAfter, operation.UpdatedAt, operation.Key)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return r.finishOperation(ctx, conn, operation)
+}
+
+// finishOperation method writes balance of the applying operation to balance service unless it is already there
+// and marks the operation as committed
+// Balance which is neither the balance before nor after the operation was changed bypassing this repository,
+// the operation stays applying until the conflict is resolved
+func (r *BalanceRepository) finishOperation(ctx context.Context, conn *pgxpool.Conn, operation *model.BalanceOperation) error {
+	balance, err := r.GetBalance(ctx, operation.ProfileID)
+	if err != nil {
+		return fmt.Errorf("GetBalance: %w", err)
+	}
+	switch {
+	case sameBalance(balance.Balance, operation.BalanceAfter):
+	case sameBalance(balance.Balance, operation.BalanceBefore):
+		balance.Balance = operation.BalanceAfter
+		updateCtx, cancel := context.WithTimeout(ctx, balanceUpdateTimeout)
+		err = r.UpdateBalance(updateCtx, balance)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("UpdateBalance: %w", err)
+		}
+	default:
+		return fmt.Errorf("balance of profile %v is %v, expected %v before or %v after operation %v",
+			operation.ProfileID, balance.Balance, operation.BalanceBefore, operation.BalanceAfter, operation.Key)
+	}
+	_, err = conn.Exec(ctx, "UPDATE trading.balance_operations SET status=$1, updated_at=$2 WHERE key=$3 AND status=$4",
+		model.BalanceOperationCommitted, time.Now(), operation.Key, model.BalanceOperationApplying)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	operation.Status = model.BalanceOperationCommitted
+	return nil
+}
+
+// finishInterruptedOperation method finishes applying operation left by a failure
+// Operation is finished only after its call of balance service timed out, so the late call can't overwrite newer balance
+func (r *BalanceRepository) finishInterruptedOperation(ctx context.Context, conn *pgxpool.Conn, operation *model.BalanceOperation) error {
+	if time.Since(operation.UpdatedAt) < balanceUpdateTimeout {
+		return fmt.Errorf("operation %v of profile %v is being applied", operation.Key, operation.ProfileID)
+	}
+	return r.finishOperation(ctx, conn, operation)
+}
+
+// getSettledBalance method finishes applying operations of the profile left by failures and returns its balance
+func (r *BalanceRepository) getSettledBalance(ctx context.Context, conn *pgxpool.Conn, profileID uuid.UUID) (*model.Balance, error) {
+	operations, err := getApplyingOperations(ctx, conn, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("getApplyingOperations: %w", err)
+	}
+	for _, operation := range operations {
+		err = r.finishInterruptedOperation(ctx, conn, operation)
+		if err != nil {
+			return nil, fmt.Errorf("finishInterruptedOperation: %w", err)
+		}
+	}
+	balance, err := r.GetBalance(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBalance: %w", err)
+	}
+	return balance, nil
+}
+
+// withProfileLock method runs fn on a connection holding session advisory lock of the profile
+// Lock is held while balance service is called, so balance of a profile is changed by one instance at a time
+func (r *BalanceRepository) withProfileLock(ctx context.Context, profileID uuid.UUID, fn func(*pgxpool.Conn) error) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("Acquire: %w", err)
+	}
+	defer conn.Release()
+	_, err = conn.Exec(ctx, "SELECT pg_advisory_lock(hashtext($1))", profileID.String())
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	defer func() {
+		// lock is released even if ctx is done, connection holding the lock is closed instead of being returned to the pool
+		_, unlockErr := conn.Exec(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", profileID.String())
+		if unlockErr != nil {
+			logrus.Errorf("pg_advisory_unlock: %v", unlockErr)
+			closeErr := conn.Conn().Close(context.Background())
+			if closeErr != nil {
+				logrus.Errorf("Close: %v", closeErr)
+			}
+		}
+	}()
+	return fn(conn)
+}
+
+// queryRower interface is implemented by both pool and connection
+type queryRower interface {
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+// balanceOperationColumns are columns of trading.balance_operations in order of scanBalanceOperation
+const balanceOperationColumns = "key, profile_id, amount, status, balance_before, balance_after, updated_at"
+
+// scanBalanceOperation function scans balance operation from a row
+func scanBalanceOperation(row pgx.Row) (*model.BalanceOperation, error) {
+	operation := &model.BalanceOperation{}
+	err := row.Scan(&operation.Key, &operation.ProfileID, &operation.Amount, &operation.Status, &operation.BalanceBefore, &operation.BalanceAfter, &operation.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// getBalanceOperation function returns balance operation by its key or nil if there is no such operation
+func getBalanceOperation(ctx context.Context, db queryRower, key uuid.UUID) (*model.BalanceOperation, error) {
+	operation, err := scanBalanceOperation(db.QueryRow(ctx, "SELECT "+balanceOperationColumns+" FROM trading.balance_operations WHERE key=$1", key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow: %w", err)
+	}
+	return operation, nil
+}
+
+// getApplyingOperations function returns applying operations of the profile
+func getApplyingOperations(ctx context.Context, conn *pgxpool.Conn, profileID uuid.UUID) ([]*model.BalanceOperation, error) {
+	rows, err := conn.Query(ctx, "SELECT "+balanceOperationColumns+" FROM trading.balance_operations WHERE profile_id=$1 AND status=$2", profileID, model.BalanceOperationApplying)
+	if err != nil {
+		return nil, fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+	var operations []*model.BalanceOperation
+	for rows.Next() {
+		operation, err := scanBalanceOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan: %w", err)
+		}
+		operations = append(operations, operation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+	return operations, nil
+}
+
+// sameBalance function compares balances the way balance service stores them as float64
+func sameBalance(a, b decimal.Decimal) bool {
+	floatA, _ := a.Float64()
+	floatB, _ := b.Float64()
+	return floatA == floatB
+}
+
+// checkSameOperation function checks that repeated operation has the same parameters as the stored one
+func checkSameOperation(operation *model.BalanceOperation, profileID uuid.UUID, amount decimal.Decimal) error {
+	if operation.ProfileID != profileID || !operation.Amount.Equal(amount) {
+		return fmt.Errorf("key %v is already used by another operation", operation.Key)
+	}
+	return nil
+}
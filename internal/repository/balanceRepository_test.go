@@ -1 +1,235 @@
 package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	proto "github.com/eugenshima/balance/proto"
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+// fakeBalanceClient struct is a balance service storing balances in memory
+// Next update fails before or after the balance is stored if failBefore or failAfter is set
+type fakeBalanceClient struct {
+	proto.BalanceServiceClient
+	mu         sync.Mutex
+	balances   map[string]float64
+	updates    int
+	failBefore bool
+	failAfter  bool
+}
+
+// GetUserByID method returns stored balance of the profile
+func (c *fakeBalanceClient) GetUserByID(_ context.Context, in *proto.UserGetByIDRequest, _ ...grpc.CallOption) (*proto.UserGetByIDResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &proto.UserGetByIDResponse{Balance: &proto.Balance{ProfileID: in.ProfileID, Balance: c.balances[in.ProfileID]}}, nil
+}
+
+// UpdateUserBalance method stores balance of the profile
+func (c *fakeBalanceClient) UpdateUserBalance(_ context.Context, in *proto.UserUpdateRequest, _ ...grpc.CallOption) (*proto.UserUpdateResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failBefore {
+		c.failBefore = false
+		return nil, errors.New("connection refused")
+	}
+	c.balances[in.Balance.ProfileID] = in.Balance.Balance
+	c.updates++
+	if c.failAfter {
+		c.failAfter = false
+		return nil, errors.New("deadline exceeded")
+	}
+	return &proto.UserUpdateResponse{}, nil
+}
+
+// balance method returns stored balance of the profile
+func (c *fakeBalanceClient) balance(profileID uuid.UUID) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.balances[profileID.String()]
+}
+
+// newTestBalanceRepository function creates a balance repository over fake balance service with given balance of a new profile
+func newTestBalanceRepository(t *testing.T, balance float64) (*BalanceRepository, *fakeBalanceClient, uuid.UUID) {
+	pool := requirePool(t)
+	profileID := uuid.New()
+	client := &fakeBalanceClient{balances: map[string]float64{profileID.String(): balance}}
+	return NewBalanceRepository(client, pool), client, profileID
+}
+
+// staleOperation function moves the operation back in time, as if its call of balance service has timed out
+func staleOperation(t *testing.T, key uuid.UUID) {
+	_, err := testPool.Exec(context.Background(), "UPDATE trading.balance_operations SET updated_at=$1 WHERE key=$2", time.Now().Add(-balanceUpdateTimeout), key)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+}
+
+func TestBalanceRepositoryReserveAndCommitAreIdempotent(t *testing.T) {
+	repo, client, profileID := newTestBalanceRepository(t, 100)
+	ctx := context.Background()
+	key := uuid.New()
+	for i := 0; i < 2; i++ {
+		err := repo.Reserve(ctx, key, profileID, decimal.NewFromInt(60))
+		if err != nil {
+			t.Fatalf("Reserve #%d: %v", i+1, err)
+		}
+	}
+	err := repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(50))
+	if !errors.Is(err, model.ErrInsufficientFunds) {
+		t.Errorf("Reserve of more than available returned %v, want insufficient funds", err)
+	}
+	for i := 0; i < 2; i++ {
+		err = repo.Commit(ctx, key)
+		if err != nil {
+			t.Fatalf("Commit #%d: %v", i+1, err)
+		}
+	}
+	if balance := client.balance(profileID); balance != 40 || client.updates != 1 {
+		t.Errorf("balance is %v after %d updates, want 40 after 1 update", balance, client.updates)
+	}
+	err = repo.Release(ctx, key)
+	if err == nil {
+		t.Error("Release of committed reservation returned no error")
+	}
+}
+
+func TestBalanceRepositoryReleaseIsIdempotent(t *testing.T) {
+	repo, client, profileID := newTestBalanceRepository(t, 100)
+	ctx := context.Background()
+	key := uuid.New()
+	err := repo.Reserve(ctx, key, profileID, decimal.NewFromInt(100))
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		err = repo.Release(ctx, key)
+		if err != nil {
+			t.Fatalf("Release #%d: %v", i+1, err)
+		}
+	}
+	err = repo.Release(ctx, uuid.New())
+	if err != nil {
+		t.Errorf("Release of unknown key: %v", err)
+	}
+	err = repo.Commit(ctx, key)
+	if err == nil {
+		t.Error("Commit of released reservation returned no error")
+	}
+	err = repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(100))
+	if err != nil {
+		t.Errorf("Reserve of released money: %v", err)
+	}
+	if client.updates != 0 {
+		t.Errorf("balance was updated %d times, want 0", client.updates)
+	}
+}
+
+func TestBalanceRepositoryCreditIsIdempotent(t *testing.T) {
+	repo, client, profileID := newTestBalanceRepository(t, 100)
+	ctx := context.Background()
+	key := uuid.New()
+	for i := 0; i < 2; i++ {
+		err := repo.Credit(ctx, key, profileID, decimal.NewFromInt(25))
+		if err != nil {
+			t.Fatalf("Credit #%d: %v", i+1, err)
+		}
+	}
+	err := repo.Credit(ctx, key, profileID, decimal.NewFromInt(30))
+	if err == nil {
+		t.Error("Credit of another amount with the same key returned no error")
+	}
+	if balance := client.balance(profileID); balance != 125 || client.updates != 1 {
+		t.Errorf("balance is %v after %d updates, want 125 after 1 update", balance, client.updates)
+	}
+}
+
+func TestBalanceRepositoryFinishesInterruptedOperations(t *testing.T) {
+	tests := []struct {
+		name       string
+		failBefore bool
+		failAfter  bool
+	}{
+		{name: "balance service failed before update", failBefore: true},
+		{name: "balance service failed after update", failAfter: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, client, profileID := newTestBalanceRepository(t, 100)
+			ctx := context.Background()
+			reserveKey, creditKey := uuid.New(), uuid.New()
+			err := repo.Reserve(ctx, reserveKey, profileID, decimal.NewFromInt(40))
+			if err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+
+			client.failBefore, client.failAfter = tt.failBefore, tt.failAfter
+			err = repo.Commit(ctx, reserveKey)
+			if err == nil {
+				t.Fatal("Commit returned no error when balance service failed")
+			}
+			err = repo.Commit(ctx, reserveKey)
+			if err == nil {
+				t.Error("repeated Commit returned no error before the call of balance service timed out")
+			}
+			staleOperation(t, reserveKey)
+			err = repo.Commit(ctx, reserveKey)
+			if err != nil {
+				t.Fatalf("repeated Commit: %v", err)
+			}
+
+			client.failBefore, client.failAfter = tt.failBefore, tt.failAfter
+			err = repo.Credit(ctx, creditKey, profileID, decimal.NewFromInt(15))
+			if err == nil {
+				t.Fatal("Credit returned no error when balance service failed")
+			}
+			err = repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(1))
+			if err == nil {
+				t.Error("Reserve returned no error while credit of the profile is being applied")
+			}
+			// credit whose call timed out is finished by the next operation of the profile
+			staleOperation(t, creditKey)
+			err = repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(1))
+			if err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+			err = repo.Credit(ctx, creditKey, profileID, decimal.NewFromInt(15))
+			if err != nil {
+				t.Fatalf("repeated Credit: %v", err)
+			}
+
+			if balance := client.balance(profileID); balance != 75 || client.updates != 2 {
+				t.Errorf("balance is %v after %d updates, want 75 after 2 updates", balance, client.updates)
+			}
+		})
+	}
+}
+
+func TestBalanceRepositoryKeepsOperationOnConflict(t *testing.T) {
+	repo, client, profileID := newTestBalanceRepository(t, 100)
+	ctx := context.Background()
+	key := uuid.New()
+	client.failBefore = true
+	err := repo.Credit(ctx, key, profileID, decimal.NewFromInt(10))
+	if err == nil {
+		t.Fatal("Credit returned no error when balance service failed")
+	}
+	// balance is changed bypassing the repository, so it is unknown if the credit was applied
+	client.balances[profileID.String()] = 500
+	staleOperation(t, key)
+	err = repo.Credit(ctx, key, profileID, decimal.NewFromInt(10))
+	if err == nil {
+		t.Error("repeated Credit returned no error for balance changed outside")
+	}
+	if balance := client.balance(profileID); balance != 500 {
+		t.Errorf("balance is %v, want 500 left unchanged", balance)
+	}
+}
@@ -0,0 +1,115 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+)
+
+// FileEventSink represents a sink which appends position lifecycle events to a file as JSON lines
+type FileEventSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileEventSink opens or creates a file for events
+func NewFileEventSink(path string) (*FileEventSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("OpenFile: %w", err)
+	}
+	return &FileEventSink{file: file}, nil
+}
+
+// Publish method writes the event to the file and syncs it to disk
+func (s *FileEventSink) Publish(_ context.Context, event *model.OutboxEvent) error {
+	data, err := json.Marshal(newEventMessage(event))
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+	err = s.file.Sync()
+	if err != nil {
+		return fmt.Errorf("Sync: %w", err)
+	}
+	return nil
+}
+
+// Close method closes the file
+func (s *FileEventSink) Close() error {
+	return s.file.Close()
+}
+
+// DiscardEventSink represents a sink which drops events, it is used when publishing of events is disabled
+// so relayed events are marked as published and purged from outbox
+type DiscardEventSink struct{}
+
+// Publish method drops the event
+func (DiscardEventSink) Publish(_ context.Context, _ *model.OutboxEvent) error {
+	return nil
+}
+
+// NatsPublisher interface represents a NATS-compatible connection, it is implemented by *nats.Conn
+type NatsPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// NatsEventSink represents a sink which publishes position lifecycle events to NATS subjects "<prefix>.<event type>.<profile ID>"
+type NatsEventSink struct {
+	conn          NatsPublisher
+	subjectPrefix string
+}
+
+// NewNatsEventSink creates a new NatsEventSink
+func NewNatsEventSink(conn NatsPublisher, subjectPrefix string) *NatsEventSink {
+	return &NatsEventSink{conn: conn, subjectPrefix: subjectPrefix}
+}
+
+// Publish method publishes the event to its subject
+func (s *NatsEventSink) Publish(_ context.Context, event *model.OutboxEvent) error {
+	data, err := json.Marshal(newEventMessage(event))
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	subject := fmt.Sprintf("%s.%s.%s", s.subjectPrefix, event.Type, event.ProfileID)
+	err = s.conn.Publish(subject, data)
+	if err != nil {
+		return fmt.Errorf("Publish: %w", err)
+	}
+	return nil
+}
+
+// eventMessage struct represents a published form of outbox event
+type eventMessage struct {
+	ID         int64                 `json:"id"`
+	Type       model.OutboxEventType `json:"type"`
+	ProfileID  string                `json:"profile_id"`
+	PositionID string                `json:"position_id"`
+	Sequence   int64                 `json:"sequence"`
+	Payload    json.RawMessage       `json:"payload"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// newEventMessage function converts outbox event to its published form
+func newEventMessage(event *model.OutboxEvent) *eventMessage {
+	return &eventMessage{
+		ID:         event.ID,
+		Type:       event.Type,
+		ProfileID:  event.ProfileID.String(),
+		PositionID: event.PositionID.String(),
+		Sequence:   event.Sequence,
+		Payload:    event.Payload,
+		CreatedAt:  event.CreatedAt,
+	}
+}
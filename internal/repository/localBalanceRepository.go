@@ -0,0 +1,132 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// LocalBalanceRepository represents an in-memory stand-in of balance microservice with the same reservation protocol
+type LocalBalanceRepository struct {
+	mu             sync.Mutex
+	balances       map[uuid.UUID]decimal.Decimal
+	operations     map[uuid.UUID]*model.BalanceOperation
+	defaultBalance decimal.Decimal
+}
+
+// NewLocalBalanceRepository creates a new LocalBalanceRepository with given initial balances
+// Profiles missing in balances get defaultBalance, zero defaultBalance means that such profiles have no balance
+func NewLocalBalanceRepository(balances map[uuid.UUID]decimal.Decimal, defaultBalance decimal.Decimal) *LocalBalanceRepository {
+	repo := &LocalBalanceRepository{
+		balances:       make(map[uuid.UUID]decimal.Decimal, len(balances)),
+		operations:     make(map[uuid.UUID]*model.BalanceOperation),
+		defaultBalance: defaultBalance,
+	}
+	for profileID, balance := range balances {
+		repo.balances[profileID] = balance
+	}
+	return repo
+}
+
+// balance method returns a balance of the profile, mu must be held
+func (r *LocalBalanceRepository) balance(profileID uuid.UUID) (decimal.Decimal, error) {
+	balance, ok := r.balances[profileID]
+	if ok {
+		return balance, nil
+	}
+	if r.defaultBalance.IsZero() {
+		return decimal.Zero, fmt.Errorf("balance of profile %v not found", profileID)
+	}
+	r.balances[profileID] = r.defaultBalance
+	return r.defaultBalance, nil
+}
+
+// GetBalance method returns a balance by given ID
+func (r *LocalBalanceRepository) GetBalance(_ context.Context, profileID uuid.UUID) (*model.Balance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	balance, err := r.balance(profileID)
+	if err != nil {
+		return nil, fmt.Errorf("balance: %w", err)
+	}
+	return &model.Balance{ProfileID: profileID, Balance: balance}, nil
+}
+
+// Reserve method holds given amount of money on the balance, repeated call with the same key does nothing
+func (r *LocalBalanceRepository) Reserve(_ context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if operation, ok := r.operations[key]; ok {
+		return checkSameOperation(operation, profileID, amount.Neg())
+	}
+	balance, err := r.balance(profileID)
+	if err != nil {
+		return fmt.Errorf("balance: %w", err)
+	}
+	for _, operation := range r.operations {
+		if operation.ProfileID == profileID && operation.Status == model.BalanceOperationReserved {
+			balance = balance.Add(operation.Amount)
+		}
+	}
+	if balance.LessThan(amount) {
+		return fmt.Errorf("available %v, requested %v: %w", balance, amount, model.ErrInsufficientFunds)
+	}
+	r.operations[key] = &model.BalanceOperation{Key: key, ProfileID: profileID, Amount: amount.Neg(), Status: model.BalanceOperationReserved}
+	return nil
+}
+
+// Commit method debits reserved money from the balance, repeated call with the same key does nothing
+func (r *LocalBalanceRepository) Commit(_ context.Context, key uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	operation, ok := r.operations[key]
+	if !ok {
+		return fmt.Errorf("reservation %v not found", key)
+	}
+	switch operation.Status {
+	case model.BalanceOperationCommitted:
+		return nil
+	case model.BalanceOperationReleased:
+		return fmt.Errorf("reservation %v is already released", key)
+	}
+	r.balances[operation.ProfileID] = r.balances[operation.ProfileID].Add(operation.Amount)
+	operation.Status = model.BalanceOperationCommitted
+	return nil
+}
+
+// Release method cancels the reservation, repeated call or call with unknown key does nothing
+func (r *LocalBalanceRepository) Release(_ context.Context, key uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	operation, ok := r.operations[key]
+	if !ok {
+		return nil
+	}
+	if operation.Status == model.BalanceOperationCommitted {
+		return fmt.Errorf("reservation %v is already committed", key)
+	}
+	operation.Status = model.BalanceOperationReleased
+	return nil
+}
+
+// Credit method adds given amount of money to the balance, repeated call with the same key does nothing
+func (r *LocalBalanceRepository) Credit(_ context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if operation, ok := r.operations[key]; ok {
+		return checkSameOperation(operation, profileID, amount)
+	}
+	balance, err := r.balance(profileID)
+	if err != nil {
+		return fmt.Errorf("balance: %w", err)
+	}
+	r.balances[profileID] = balance.Add(amount)
+	r.operations[key] = &model.BalanceOperation{Key: key, ProfileID: profileID, Amount: amount, Status: model.BalanceOperationCommitted}
+	return nil
+}
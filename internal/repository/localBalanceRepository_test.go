@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestLocalBalanceRepositoryReserveAndCommitAreIdempotent(t *testing.T) {
+	profileID := uuid.New()
+	repo := NewLocalBalanceRepository(map[uuid.UUID]decimal.Decimal{profileID: decimal.NewFromInt(100)}, decimal.Zero)
+	ctx := context.Background()
+	key := uuid.New()
+	for i := 0; i < 2; i++ {
+		err := repo.Reserve(ctx, key, profileID, decimal.NewFromInt(60))
+		if err != nil {
+			t.Fatalf("Reserve #%d: %v", i+1, err)
+		}
+	}
+	err := repo.Reserve(ctx, key, profileID, decimal.NewFromInt(70))
+	if err == nil {
+		t.Error("Reserve of another amount with the same key returned no error")
+	}
+	err = repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(50))
+	if !errors.Is(err, model.ErrInsufficientFunds) {
+		t.Errorf("Reserve of more than available returned %v, want insufficient funds", err)
+	}
+	for i := 0; i < 2; i++ {
+		err = repo.Commit(ctx, key)
+		if err != nil {
+			t.Fatalf("Commit #%d: %v", i+1, err)
+		}
+	}
+	err = repo.Release(ctx, key)
+	if err == nil {
+		t.Error("Release of committed reservation returned no error")
+	}
+	balance, err := repo.GetBalance(ctx, profileID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if !balance.Balance.Equal(decimal.NewFromInt(40)) {
+		t.Errorf("balance is %v, want 40", balance.Balance)
+	}
+}
+
+func TestLocalBalanceRepositoryReleaseIsIdempotent(t *testing.T) {
+	profileID := uuid.New()
+	repo := NewLocalBalanceRepository(map[uuid.UUID]decimal.Decimal{profileID: decimal.NewFromInt(100)}, decimal.Zero)
+	ctx := context.Background()
+	key := uuid.New()
+	err := repo.Reserve(ctx, key, profileID, decimal.NewFromInt(100))
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		err = repo.Release(ctx, key)
+		if err != nil {
+			t.Fatalf("Release #%d: %v", i+1, err)
+		}
+	}
+	err = repo.Release(ctx, uuid.New())
+	if err != nil {
+		t.Errorf("Release of unknown key: %v", err)
+	}
+	err = repo.Commit(ctx, key)
+	if err == nil {
+		t.Error("Commit of released reservation returned no error")
+	}
+	err = repo.Reserve(ctx, uuid.New(), profileID, decimal.NewFromInt(100))
+	if err != nil {
+		t.Errorf("Reserve of released money: %v", err)
+	}
+}
+
+func TestLocalBalanceRepositoryCreditIsIdempotent(t *testing.T) {
+	profileID := uuid.New()
+	repo := NewLocalBalanceRepository(nil, decimal.NewFromInt(100))
+	ctx := context.Background()
+	key := uuid.New()
+	for i := 0; i < 2; i++ {
+		err := repo.Credit(ctx, key, profileID, decimal.NewFromInt(25))
+		if err != nil {
+			t.Fatalf("Credit #%d: %v", i+1, err)
+		}
+	}
+	err := repo.Credit(ctx, key, profileID, decimal.NewFromInt(30))
+	if err == nil {
+		t.Error("Credit of another amount with the same key returned no error")
+	}
+	balance, err := repo.GetBalance(ctx, profileID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if !balance.Balance.Equal(decimal.NewFromInt(125)) {
+		t.Errorf("balance is %v, want 125", balance.Balance)
+	}
+}
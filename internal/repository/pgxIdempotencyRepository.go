@@ -0,0 +1,88 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// ClaimIdempotencyKey method stores a pending record of the key, returns false if the key is already taken by a record which is not expired
+func (repo *TradingRepository) ClaimIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) (bool, error) {
+	tag, err := repo.pool.Exec(
+		ctx,
+		`INSERT INTO trading.idempotency_keys (profile_id, key, operation, request_hash, status, response, created_at, expires_at, locked_until) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT (profile_id, key, operation) DO UPDATE SET request_hash=$4, status=$5, response=$6, created_at=$7, expires_at=$8, locked_until=$9
+		WHERE trading.idempotency_keys.expires_at<=$7`,
+		record.ProfileID, record.Key, record.Operation, record.RequestHash, record.Status, record.Response, record.CreatedAt, record.ExpiresAt, record.LockedUntil)
+	if err != nil {
+		return false, fmt.Errorf("exec: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetIdempotencyRecord method returns the record of the key of given operation made by the profile
+func (repo *TradingRepository) GetIdempotencyRecord(ctx context.Context, profileID uuid.UUID, key string, operation model.IdempotencyOperation) (*model.IdempotencyRecord, error) {
+	record := &model.IdempotencyRecord{}
+	err := repo.pool.QueryRow(
+		ctx,
+		"SELECT profile_id, key, operation, request_hash, status, response, created_at, expires_at, locked_until FROM trading.idempotency_keys WHERE profile_id=$1 AND key=$2 AND operation=$3",
+		profileID, key, operation).Scan(&record.ProfileID, &record.Key, &record.Operation, &record.RequestHash, &record.Status, &record.Response, &record.CreatedAt, &record.ExpiresAt, &record.LockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("QueryRow: %w", model.ErrIdempotencyKeyNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow: %w", err)
+	}
+	return record, nil
+}
+
+// ReclaimIdempotencyKey method extends the lease of pending record of the same request whose lease is over by time of creation of given record
+// Returns false if the record is completed, expired, made by a different request or reclaimed by another retry
+func (repo *TradingRepository) ReclaimIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) (bool, error) {
+	tag, err := repo.pool.Exec(
+		ctx,
+		`UPDATE trading.idempotency_keys SET locked_until=$1
+		WHERE profile_id=$2 AND key=$3 AND operation=$4 AND request_hash=$5 AND status=$6 AND locked_until<=$7 AND expires_at>$7`,
+		record.LockedUntil, record.ProfileID, record.Key, record.Operation, record.RequestHash, model.IdempotencyStatusPending, record.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("exec: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CompleteIdempotencyKey method stores the response of the request made with the key
+func (repo *TradingRepository) CompleteIdempotencyKey(ctx context.Context, record *model.IdempotencyRecord) error {
+	_, err := repo.pool.Exec(
+		ctx,
+		"UPDATE trading.idempotency_keys SET status=$1, response=$2 WHERE profile_id=$3 AND key=$4 AND operation=$5",
+		record.Status, record.Response, record.ProfileID, record.Key, record.Operation)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey method frees the key of failed request, so the request can be retried with it
+func (repo *TradingRepository) DeleteIdempotencyKey(ctx context.Context, profileID uuid.UUID, key string, operation model.IdempotencyOperation) error {
+	_, err := repo.pool.Exec(ctx, "DELETE FROM trading.idempotency_keys WHERE profile_id=$1 AND key=$2 AND operation=$3", profileID, key, operation)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys method deletes records whose retention window is over by given time
+func (repo *TradingRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := repo.pool.Exec(ctx, "DELETE FROM trading.idempotency_keys WHERE expires_at<=$1", now)
+	if err != nil {
+		return 0, fmt.Errorf("exec: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
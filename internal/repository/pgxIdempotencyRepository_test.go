@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestReclaimIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		lockedFor   time.Duration
+		requestHash string
+		status      model.IdempotencyStatus
+		want        bool
+	}{
+		{name: "stale pending key", lockedFor: -time.Second, requestHash: "hash", status: model.IdempotencyStatusPending, want: true},
+		{name: "pending key within lease", lockedFor: time.Minute, requestHash: "hash", status: model.IdempotencyStatusPending},
+		{name: "stale key of different request", lockedFor: -time.Second, requestHash: "other", status: model.IdempotencyStatusPending},
+		{name: "completed key", lockedFor: -time.Second, requestHash: "hash", status: model.IdempotencyStatusCompleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewTradingRepository(requirePool(t))
+			ctx := context.Background()
+			now := time.Now()
+			record := &model.IdempotencyRecord{
+				ProfileID: uuid.New(), Key: "key", Operation: model.IdempotencyOpenPosition, RequestHash: "hash",
+				Status: model.IdempotencyStatusPending, Response: []byte("{}"), CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: now.Add(tt.lockedFor),
+			}
+			claimed, err := repo.ClaimIdempotencyKey(ctx, record)
+			if err != nil || !claimed {
+				t.Fatalf("ClaimIdempotencyKey: %v, %v", claimed, err)
+			}
+			record.Status = tt.status
+			err = repo.CompleteIdempotencyKey(ctx, record)
+			if err != nil {
+				t.Fatalf("CompleteIdempotencyKey: %v", err)
+			}
+
+			retry := *record
+			retry.RequestHash = tt.requestHash
+			retry.CreatedAt = time.Now()
+			retry.LockedUntil = retry.CreatedAt.Add(time.Minute)
+			claimed, err = repo.ClaimIdempotencyKey(ctx, &retry)
+			if err != nil || claimed {
+				t.Fatalf("ClaimIdempotencyKey of retry: %v, %v, want the key to be taken", claimed, err)
+			}
+			reclaimed, err := repo.ReclaimIdempotencyKey(ctx, &retry)
+			if err != nil {
+				t.Fatalf("ReclaimIdempotencyKey: %v", err)
+			}
+			if reclaimed != tt.want {
+				t.Errorf("ReclaimIdempotencyKey returned %v, want %v", reclaimed, tt.want)
+			}
+			if !reclaimed {
+				return
+			}
+			// the same stale lease is reclaimed only once
+			reclaimed, err = repo.ReclaimIdempotencyKey(ctx, &retry)
+			if err != nil || reclaimed {
+				t.Errorf("second ReclaimIdempotencyKey: %v, %v, want false", reclaimed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// instrumentColumns is a list of trading.instruments columns read by scanInstrument
+const instrumentColumns = "share_name, tick_size, min_lot, quantity_scale, currency, min_order_value, max_order_value, tradable, created_at, updated_at"
+
+// CreateInstrument method persists a new instrument
+func (repo *TradingRepository) CreateInstrument(ctx context.Context, instrument *model.Instrument) error {
+	_, err := repo.pool.Exec(
+		ctx,
+		"INSERT INTO trading.instruments ("+instrumentColumns+") VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)",
+		instrument.ShareName, instrument.TickSize, instrument.MinLot, instrument.QuantityScale, instrument.Currency,
+		instrument.MinOrderValue, instrument.MaxOrderValue, instrument.Tradable, instrument.CreatedAt, instrument.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// UpdateInstrument method replaces trading rules of the instrument of given share
+func (repo *TradingRepository) UpdateInstrument(ctx context.Context, instrument *model.Instrument) error {
+	tag, err := repo.pool.Exec(
+		ctx,
+		`UPDATE trading.instruments SET tick_size=$1, min_lot=$2, quantity_scale=$3, currency=$4, min_order_value=$5, max_order_value=$6, tradable=$7, updated_at=$8
+		WHERE share_name=$9`,
+		instrument.TickSize, instrument.MinLot, instrument.QuantityScale, instrument.Currency,
+		instrument.MinOrderValue, instrument.MaxOrderValue, instrument.Tradable, instrument.UpdatedAt, instrument.ShareName)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("exec: %w", model.ErrInstrumentNotFound)
+	}
+	return nil
+}
+
+// GetInstruments method returns all the instruments from database
+func (repo *TradingRepository) GetInstruments(ctx context.Context) ([]*model.Instrument, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+instrumentColumns+" FROM trading.instruments ORDER BY share_name")
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var instruments []*model.Instrument
+	for rows.Next() {
+		instrument, err := scanInstrument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		instruments = append(instruments, instrument)
+	}
+	return instruments, rows.Err()
+}
+
+// scanInstrument function scans a row of instrumentColumns into instrument
+func scanInstrument(row pgx.Row) (*model.Instrument, error) {
+	instrument := &model.Instrument{}
+	err := row.Scan(&instrument.ShareName, &instrument.TickSize, &instrument.MinLot, &instrument.QuantityScale, &instrument.Currency,
+		&instrument.MinOrderValue, &instrument.MaxOrderValue, &instrument.Tradable, &instrument.CreatedAt, &instrument.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return instrument, nil
+}
@@ -0,0 +1,194 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// orderColumns is a list of trading.orders columns read by scanOrder
+const orderColumns = "id, profile_id, share_name, type, trigger_price, total, stop_loss, take_profit, status, position_id, error, created_at, updated_at, parent_id, time_in_force, expires_at"
+
+// CreateOrders method persists new orders within one transaction, so entry order of a bracket is stored together with its exit orders
+func (repo *TradingRepository) CreateOrders(ctx context.Context, orders ...*model.Order) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	for _, order := range orders {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO trading.orders ("+orderColumns+") VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)",
+			order.ID, order.ProfileID, order.ShareName, order.Type, order.TriggerPrice, order.Total, order.StopLoss, order.TakeProfit,
+			order.Status, order.PositionID, order.Error, order.CreatedAt, order.UpdatedAt, order.ParentID, order.TimeInForce, order.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetOrderByID method returns the order of the given ID from database
+func (repo *TradingRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*model.Order, error) {
+	order, err := scanOrder(repo.pool.QueryRow(ctx, "SELECT "+orderColumns+" FROM trading.orders WHERE id=$1", orderID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("QueryRow: %w", model.ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow: %w", err)
+	}
+	return order, nil
+}
+
+// UpdateOrderStatus method moves the order from given status to its current status and stores events within the same transaction
+// ErrOrderNotFound is returned if the order is missing or it is not in given status anymore
+func (repo *TradingRepository) UpdateOrderStatus(ctx context.Context, order *model.Order, from model.OrderStatus, events ...*model.OutboxEvent) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE trading.orders SET status=$1, error=$2, updated_at=$3 WHERE id=$4 AND status=$5",
+		order.Status, order.Error, order.UpdatedAt, order.ID, from)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = fmt.Errorf("order %v in status %v: %w", order.ID, from, model.ErrOrderNotFound)
+		return err
+	}
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
+	return nil
+}
+
+// AmendOrder method stores new trigger price and time-in-force of the order which is not triggered yet
+func (repo *TradingRepository) AmendOrder(ctx context.Context, order *model.Order) error {
+	tag, err := repo.pool.Exec(
+		ctx,
+		"UPDATE trading.orders SET trigger_price=$1, time_in_force=$2, expires_at=$3, updated_at=$4 WHERE id=$5 AND status IN ($6, $7)",
+		order.TriggerPrice, order.TimeInForce, order.ExpiresAt, order.UpdatedAt, order.ID, model.OrderStatusPending, model.OrderStatusInactive)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("order %v is not pending: %w", order.ID, model.ErrOrderNotFound)
+	}
+	return nil
+}
+
+// GetExitOrders method returns exit orders of the entry order of given ID
+func (repo *TradingRepository) GetExitOrders(ctx context.Context, parentID uuid.UUID) ([]*model.Order, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+orderColumns+" FROM trading.orders WHERE parent_id=$1 ORDER BY created_at", parentID)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*model.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// ListOrders method returns a page of orders of the profile matching given filter ordered by ID
+func (repo *TradingRepository) ListOrders(ctx context.Context, filter *model.OrderFilter) ([]*model.Order, error) {
+	query := "SELECT " + orderColumns + " FROM trading.orders WHERE profile_id=$1 AND id > $2"
+	args := []interface{}{filter.ProfileID, filter.Cursor}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND status=$%d", len(args))
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+
+	rows, err := repo.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*model.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// GetPendingOrders method returns all pending and inactive orders from database
+func (repo *TradingRepository) GetPendingOrders(ctx context.Context) ([]*model.Order, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+orderColumns+" FROM trading.orders WHERE status IN ($1, $2) ORDER BY created_at", model.OrderStatusPending, model.OrderStatusInactive)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*model.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// scanOrder function scans a row of orderColumns into order
+func scanOrder(row pgx.Row) (*model.Order, error) {
+	order := &model.Order{}
+	err := row.Scan(
+		&order.ID, &order.ProfileID, &order.ShareName, &order.Type, &order.TriggerPrice, &order.Total, &order.StopLoss, &order.TakeProfit,
+		&order.Status, &order.PositionID, &order.Error, &order.CreatedAt, &order.UpdatedAt, &order.ParentID, &order.TimeInForce, &order.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
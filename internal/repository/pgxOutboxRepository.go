@@ -0,0 +1,105 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// insertOutboxEvents function stores events in outbox within given transaction
+// Sequence of the profile stays locked until the transaction ends, so events of a profile are committed in order of their sequence
+// and the relay never sees an event before the previous events of its profile
+func insertOutboxEvents(ctx context.Context, tx pgx.Tx, events []*model.OutboxEvent) error {
+	for _, event := range events {
+		err := tx.QueryRow(
+			ctx,
+			`INSERT INTO trading.outbox_sequences (profile_id, last_sequence) VALUES($1,1)
+			ON CONFLICT (profile_id) DO UPDATE SET last_sequence=trading.outbox_sequences.last_sequence+1 RETURNING last_sequence`,
+			event.ProfileID).Scan(&event.Sequence)
+		if err != nil {
+			return fmt.Errorf("QueryRow: %w", err)
+		}
+		err = tx.QueryRow(
+			ctx,
+			"INSERT INTO trading.outbox (type, profile_id, position_id, sequence, payload, created_at) VALUES($1,$2,$3,$4,$5,$6) RETURNING id",
+			event.Type, event.ProfileID, event.PositionID, event.Sequence, event.Payload, event.CreatedAt).Scan(&event.ID)
+		if err != nil {
+			return fmt.Errorf("QueryRow: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateOutboxEvents method stores events which are not related to a change of positions in outbox
+func (repo *TradingRepository) CreateOutboxEvents(ctx context.Context, events ...*model.OutboxEvent) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
+	return nil
+}
+
+// GetUnpublishedEvents method returns the oldest unpublished events of outbox, events of each profile are in order of their sequence
+func (repo *TradingRepository) GetUnpublishedEvents(ctx context.Context, limit int) ([]*model.OutboxEvent, error) {
+	rows, err := repo.pool.Query(
+		ctx,
+		"SELECT id, type, profile_id, position_id, sequence, payload, created_at FROM trading.outbox WHERE published_at IS NULL ORDER BY id LIMIT $1",
+		limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var events []*model.OutboxEvent
+	for rows.Next() {
+		event := &model.OutboxEvent{}
+		err := rows.Scan(&event.ID, &event.Type, &event.ProfileID, &event.PositionID, &event.Sequence, &event.Payload, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// DeletePublishedEvents method deletes events which were published before given time
+func (repo *TradingRepository) DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := repo.pool.Exec(ctx, "DELETE FROM trading.outbox WHERE published_at<$1", before)
+	if err != nil {
+		return 0, fmt.Errorf("exec: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// MarkEventsPublished method marks events of given IDs as published
+func (repo *TradingRepository) MarkEventsPublished(ctx context.Context, IDs []int64) error {
+	_, err := repo.pool.Exec(ctx, "UPDATE trading.outbox SET published_at=now() WHERE id=ANY($1)", IDs)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+)
+
+// GetRiskLimits method returns own risk limits of the profile
+func (repo *TradingRepository) GetRiskLimits(ctx context.Context, profileID uuid.UUID) (*model.RiskLimits, error) {
+	limits := &model.RiskLimits{}
+	err := repo.pool.QueryRow(
+		ctx,
+		`SELECT profile_id, max_open_positions, max_instrument_notional, max_total_exposure, max_daily_loss, max_order_size, updated_at
+		FROM trading.risk_limits WHERE profile_id=$1`,
+		profileID).Scan(&limits.ProfileID, &limits.MaxOpenPositions, &limits.MaxInstrumentNotional, &limits.MaxTotalExposure,
+		&limits.MaxDailyLoss, &limits.MaxOrderSize, &limits.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("QueryRow: %w", model.ErrRiskLimitsNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow: %w", err)
+	}
+	return limits, nil
+}
+
+// SetRiskLimits method stores own risk limits of the profile replacing the previous ones
+func (repo *TradingRepository) SetRiskLimits(ctx context.Context, limits *model.RiskLimits) error {
+	_, err := repo.pool.Exec(
+		ctx,
+		`INSERT INTO trading.risk_limits (profile_id, max_open_positions, max_instrument_notional, max_total_exposure, max_daily_loss, max_order_size, updated_at)
+		VALUES($1,$2,$3,$4,$5,$6,$7)
+		ON CONFLICT (profile_id) DO UPDATE SET max_open_positions=$2, max_instrument_notional=$3, max_total_exposure=$4, max_daily_loss=$5, max_order_size=$6, updated_at=$7`,
+		limits.ProfileID, limits.MaxOpenPositions, limits.MaxInstrumentNotional, limits.MaxTotalExposure, limits.MaxDailyLoss, limits.MaxOrderSize, limits.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// GetRealizedPnL method returns net realized PnL of positions of the profile closed since given time
+func (repo *TradingRepository) GetRealizedPnL(ctx context.Context, profileID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	var realizedPnL decimal.Decimal
+	err := repo.pool.QueryRow(
+		ctx,
+		"SELECT COALESCE(SUM(realized_pnl - open_fee - close_fee), 0) FROM trading.trading WHERE profile_id=$1 AND status=$2 AND closed_at>=$3",
+		profileID, model.PositionStatusClosed, since).Scan(&realizedPnL)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("QueryRow: %w", err)
+	}
+	return realizedPnL, nil
+}
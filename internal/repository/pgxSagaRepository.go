@@ -0,0 +1,75 @@
+// Package repository contains methods to communicate with postgres and gRPC servers
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// CreateSaga method persists a new saga
+func (repo *TradingRepository) CreateSaga(ctx context.Context, saga *model.Saga) error {
+	position, err := json.Marshal(saga.Position)
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	_, err = repo.pool.Exec(
+		ctx,
+		"INSERT INTO trading.sagas (id, type, step, status, position, amount, share_price, pnl, reason, error, created_at, updated_at) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)",
+		saga.ID, saga.Type, saga.Step, saga.Status, position, saga.Amount, saga.SharePrice, saga.PnL, saga.Reason, saga.Error, saga.CreatedAt, saga.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// UpdateSaga method updates step, status and error of the saga
+func (repo *TradingRepository) UpdateSaga(ctx context.Context, saga *model.Saga) error {
+	_, err := repo.pool.Exec(ctx, "UPDATE trading.sagas SET step=$1, status=$2, error=$3, updated_at=$4 WHERE id=$5", saga.Step, saga.Status, saga.Error, saga.UpdatedAt, saga.ID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// GetPendingSagas method returns pending sagas which were not updated since given time
+func (repo *TradingRepository) GetPendingSagas(ctx context.Context, updatedBefore time.Time) ([]*model.Saga, error) {
+	rows, err := repo.pool.Query(
+		ctx,
+		"SELECT id, type, step, status, position, amount, share_price, pnl, reason, error, created_at, updated_at FROM trading.sagas WHERE status=$1 AND updated_at<$2 ORDER BY created_at",
+		model.SagaStatusPending, updatedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var sagas []*model.Saga
+	for rows.Next() {
+		saga := &model.Saga{}
+		var position []byte
+		err := rows.Scan(&saga.ID, &saga.Type, &saga.Step, &saga.Status, &position, &saga.Amount, &saga.SharePrice, &saga.PnL, &saga.Reason, &saga.Error, &saga.CreatedAt, &saga.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		err = json.Unmarshal(position, &saga.Position)
+		if err != nil {
+			return nil, fmt.Errorf("Unmarshal: %w", err)
+		}
+		sagas = append(sagas, saga)
+	}
+	return sagas, rows.Err()
+}
+
+// updateSagaStep function stores a step of the saga within the transaction which does this step
+func updateSagaStep(ctx context.Context, tx pgx.Tx, saga *model.Saga) error {
+	_, err := tx.Exec(ctx, "UPDATE trading.sagas SET step=$1, updated_at=$2 WHERE id=$3", saga.Step, saga.UpdatedAt, saga.ID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
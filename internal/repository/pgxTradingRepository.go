@@ -3,16 +3,22 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eugenshima/trading-service/internal/model"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
+// positionColumns is a list of trading.trading columns read by scanPosition
+const positionColumns = "id, profile_id, is_long, share_name, share_price, total, shares_amount, stop_loss, take_profit, status, exit_price, realized_pnl, close_reason, opened_at, closed_at, trailing_stop_type, trailing_stop_distance, parent_id, leverage, expires_at, open_fee, close_fee, version"
+
 // TradingRepository structure ....
 type TradingRepository struct {
 	pool *pgxpool.Pool
@@ -23,62 +29,246 @@ func NewTradingRepository(pool *pgxpool.Pool) *TradingRepository {
 	return &TradingRepository{pool: pool}
 }
 
-// CreatePosition method creates a new Position
-func (repo *TradingRepository) CreatePosition(ctx context.Context, position *model.Position) error {
+// CreatePosition method creates a new Position of the opening saga and stores given events in outbox within the same transaction
+// Saga step is updated within the same transaction, so the saga knows whether the position was created
+func (repo *TradingRepository) CreatePosition(ctx context.Context, saga *model.Saga, events ...*model.OutboxEvent) (err error) {
 	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "repeatable read"})
 	if err != nil {
 		return fmt.Errorf("BeginTx: %w", err)
 	}
 	defer func() {
 		if err != nil {
-			err = tx.Rollback(ctx)
-			if err != nil {
-				logrus.Errorf("Rollback: %v", err)
-				return
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
 			}
-		} else {
-			err = tx.Commit(ctx)
-			if err != nil {
-				logrus.Errorf("Commit: %v", err)
-				return
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	position := saga.Position
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO trading.trading (id, profile_id, is_long, share_name, share_price, total, shares_amount, stop_loss, take_profit, status, opened_at, trailing_stop_type, trailing_stop_distance, leverage, expires_at, open_fee) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)",
+		position.ID, position.ProfileID, position.IsLong, position.ShareName, position.SharePrice, position.Total, position.ShareAmount, position.StopLoss, position.TakeProfit, model.PositionStatusOpen, position.OpenedAt,
+		position.TrailingStopType, position.TrailingStopDistance, position.Leverage, position.ExpiresAt, position.OpenFee)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	err = updateSagaStep(ctx, tx, saga)
+	if err != nil {
+		return fmt.Errorf("updateSagaStep: %w", err)
+	}
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
+	return nil
+}
+
+// StartClosingPosition method moves an opened position of the closing saga to closing status if it was not changed since it was read
+// Saga step is updated within the same transaction, so the saga knows whether it has to open the position again
+func (repo *TradingRepository) StartClosingPosition(ctx context.Context, saga *model.Saga) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
 			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
 		}
 	}()
+	position := saga.Position
+	err = lockPosition(ctx, tx, position.ID, model.PositionStatusOpen, position.Version)
+	if err != nil {
+		return fmt.Errorf("lockPosition: %w", err)
+	}
+	_, err = tx.Exec(ctx, "UPDATE trading.trading SET status=$1, version=version+1 WHERE id=$2", model.PositionStatusClosing, position.ID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	err = updateSagaStep(ctx, tx, saga)
+	if err != nil {
+		return fmt.Errorf("updateSagaStep: %w", err)
+	}
+	return nil
+}
+
+// ReopenPosition method moves a closing position back to open status if its closing failed, position which is not closing stays as it is
+func (repo *TradingRepository) ReopenPosition(ctx context.Context, positionID uuid.UUID) error {
+	_, err := repo.pool.Exec(ctx, "UPDATE trading.trading SET status=$1, version=version+1 WHERE id=$2 AND status=$3",
+		model.PositionStatusOpen, positionID, model.PositionStatusClosing)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	return nil
+}
+
+// ClosePosition method moves a closing position of the saga to history with its exit price, realized PnL and close reason
+// and stores given events in outbox within the same transaction
+// Saga step is updated within the same transaction, so the saga knows whether settlement of the position has to be credited
+func (repo *TradingRepository) ClosePosition(ctx context.Context, saga *model.Saga, events ...*model.OutboxEvent) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	position := saga.Position
+	err = lockPosition(ctx, tx, position.ID, model.PositionStatusClosing, position.Version)
+	if err != nil {
+		return fmt.Errorf("lockPosition: %w", err)
+	}
 	_, err = tx.Exec(
 		ctx,
-		"INSERT INTO trading.trading VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)",
-		position.ID, position.ProfileID, position.IsLong, position.ShareName, position.SharePrice, position.Total, position.ShareAmount, position.StopLoss, position.TakeProfit)
+		"UPDATE trading.trading SET status=$1, exit_price=$2, realized_pnl=$3, close_reason=$4, closed_at=$5, close_fee=$6, version=version+1 WHERE id=$7",
+		model.PositionStatusClosed, position.ExitPrice, position.RealizedPnL, position.CloseReason, position.ClosedAt, position.CloseFee, position.ID)
 	if err != nil {
 		return fmt.Errorf("exec: %w", err)
 	}
+	err = updateSagaStep(ctx, tx, saga)
+	if err != nil {
+		return fmt.Errorf("updateSagaStep: %w", err)
+	}
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
 	return nil
 }
 
-// DeletePosition method deletes a position from database
-func (repo *TradingRepository) DeletePosition(ctx context.Context, ID uuid.UUID) error {
-	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "repeatable read"})
+// ReducePosition method stores the remaining part of partially closed position and its closed part of the saga as a separate
+// closed position if the position was not changed since it was read and stores given events in outbox within the same transaction
+// Saga step is updated within the same transaction, so the saga knows whether settlement of the closed part has to be credited
+func (repo *TradingRepository) ReducePosition(ctx context.Context, saga *model.Saga, remaining *model.Position, events ...*model.OutboxEvent) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
 	if err != nil {
 		return fmt.Errorf("BeginTx: %w", err)
 	}
 	defer func() {
 		if err != nil {
-			err = tx.Rollback(ctx)
-			if err != nil {
-				logrus.Errorf("Rollback: %v", err)
-				return
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
 			}
-		} else {
-			err = tx.Commit(ctx)
-			if err != nil {
-				logrus.Errorf("Commit: %v", err)
-				return
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
+		}
+	}()
+	closedPart := saga.Position
+	err = lockPosition(ctx, tx, remaining.ID, model.PositionStatusOpen, remaining.Version)
+	if err != nil {
+		return fmt.Errorf("lockPosition: %w", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE trading.trading SET total=$1, shares_amount=$2, open_fee=$3, version=version+1 WHERE id=$4",
+		remaining.Total, remaining.ShareAmount, remaining.OpenFee, remaining.ID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO trading.trading ("+positionColumns+") VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)",
+		closedPart.ID, closedPart.ProfileID, closedPart.IsLong, closedPart.ShareName, closedPart.SharePrice, closedPart.Total, closedPart.ShareAmount, closedPart.StopLoss, closedPart.TakeProfit,
+		model.PositionStatusClosed, closedPart.ExitPrice, closedPart.RealizedPnL, closedPart.CloseReason, closedPart.OpenedAt, closedPart.ClosedAt,
+		closedPart.TrailingStopType, closedPart.TrailingStopDistance, closedPart.ParentID, closedPart.Leverage, closedPart.ExpiresAt, closedPart.OpenFee, closedPart.CloseFee, closedPart.Version)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	err = updateSagaStep(ctx, tx, saga)
+	if err != nil {
+		return fmt.Errorf("updateSagaStep: %w", err)
+	}
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
+	return nil
+}
+
+// IncreasePosition method stores increased position of the saga if it was not changed since previous state was read
+// Saga step is updated within the same transaction, so the saga knows whether the position was increased
+func (repo *TradingRepository) IncreasePosition(ctx context.Context, saga *model.Saga, previous *model.Position, events ...*model.OutboxEvent) (err error) {
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "read committed"})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				logrus.Errorf("Rollback: %v", rollbackErr)
 			}
+			return
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			logrus.Errorf("Commit: %v", err)
+			err = fmt.Errorf("Commit: %w", err)
 		}
 	}()
-	_, err = tx.Exec(ctx, "DELETE FROM trading.trading WHERE id=$1", ID)
+	position := saga.Position
+	err = lockPosition(ctx, tx, previous.ID, model.PositionStatusOpen, previous.Version)
+	if err != nil {
+		return fmt.Errorf("lockPosition: %w", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE trading.trading SET share_price=$1, total=$2, shares_amount=$3, open_fee=$4, version=version+1 WHERE id=$5",
+		position.SharePrice, position.Total, position.ShareAmount, position.OpenFee, position.ID)
 	if err != nil {
 		return fmt.Errorf("exec: %w", err)
 	}
+	err = updateSagaStep(ctx, tx, saga)
+	if err != nil {
+		return fmt.Errorf("updateSagaStep: %w", err)
+	}
+	err = insertOutboxEvents(ctx, tx, events)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvents: %w", err)
+	}
+	return nil
+}
+
+// UpdateStopLoss method stores a new stop loss level of an opened position
+func (repo *TradingRepository) UpdateStopLoss(ctx context.Context, positionID uuid.UUID, stopLoss decimal.Decimal) error {
+	tag, err := repo.pool.Exec(ctx, "UPDATE trading.trading SET stop_loss=$1 WHERE id=$2 AND status=$3", stopLoss, positionID, model.PositionStatusOpen)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotFound)
+	}
 	return nil
 }
 
@@ -103,15 +293,17 @@ func (repo *TradingRepository) GetPositionByID(ctx context.Context, PositionID u
 			}
 		}
 	}()
-	position := &model.Position{}
-	err = tx.QueryRow(ctx, "SELECT id, profile_id, is_long, share_name, share_price, total, shares_amount, stop_loss, take_profit FROM trading.trading WHERE id=$1", PositionID).Scan(&position.ID, &position.ProfileID, &position.IsLong, &position.ShareName, &position.SharePrice, &position.Total, &position.ShareAmount, &position.StopLoss, &position.TakeProfit)
+	position, err := scanPosition(tx.QueryRow(ctx, "SELECT "+positionColumns+" FROM trading.trading WHERE id=$1", PositionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("QueryRow: %w", model.ErrPositionNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("QueryRow: %w", err)
 	}
 	return position, nil
 }
 
-// GetAllIDsPositions functions returns all positions of the given user
+// GetAllIDsPositions functions returns all opened positions of the given user
 func (repo *TradingRepository) GetAllIDsPositions(ctx context.Context, profileID uuid.UUID) ([]*model.Position, error) {
 	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: "repeatable read"})
 	if err != nil {
@@ -132,7 +324,7 @@ func (repo *TradingRepository) GetAllIDsPositions(ctx context.Context, profileID
 			}
 		}
 	}()
-	rows, err := tx.Query(ctx, "SELECT id, profile_id, is_long, share_name, share_price, total, shares_amount, stop_loss, take_profit FROM trading.trading")
+	rows, err := tx.Query(ctx, "SELECT "+positionColumns+" FROM trading.trading WHERE profile_id=$1 AND status=$2", profileID, model.PositionStatusOpen)
 	if err != nil {
 		return nil, fmt.Errorf("Query(): %w", err)
 	}
@@ -141,8 +333,7 @@ func (repo *TradingRepository) GetAllIDsPositions(ctx context.Context, profileID
 	var positions []*model.Position
 
 	for rows.Next() {
-		position := &model.Position{}
-		err := rows.Scan(&position.ID, &position.ProfileID, &position.IsLong, &position.ShareName, &position.SharePrice, &position.Total, &position.ShareAmount, &position.StopLoss, &position.TakeProfit)
+		position, err := scanPosition(rows)
 		if err != nil {
 			return nil, fmt.Errorf("Scan(): %w", err)
 		}
@@ -150,3 +341,145 @@ func (repo *TradingRepository) GetAllIDsPositions(ctx context.Context, profileID
 	}
 	return positions, rows.Err()
 }
+
+// ListPositions functions returns a page of opened positions matching given filter ordered by ID
+func (repo *TradingRepository) ListPositions(ctx context.Context, filter *model.PositionFilter) ([]*model.Position, error) {
+	query := "SELECT " + positionColumns + " FROM trading.trading WHERE status=$1 AND id > $2"
+	args := []interface{}{model.PositionStatusOpen, filter.Cursor}
+	if filter.ProfileID != uuid.Nil {
+		args = append(args, filter.ProfileID)
+		query += fmt.Sprintf(" AND profile_id=$%d", len(args))
+	}
+	if filter.ShareName != "" {
+		args = append(args, filter.ShareName)
+		query += fmt.Sprintf(" AND share_name=$%d", len(args))
+	}
+	if filter.IsLong != nil {
+		args = append(args, *filter.IsLong)
+		query += fmt.Sprintf(" AND is_long=$%d", len(args))
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+
+	rows, err := repo.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*model.Position
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		positions = append(positions, position)
+	}
+	return positions, rows.Err()
+}
+
+// GetAllPositions functions returns all opened positions from database
+func (repo *TradingRepository) GetAllPositions(ctx context.Context) ([]*model.Position, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+positionColumns+" FROM trading.trading WHERE status=$1", model.PositionStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*model.Position
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		positions = append(positions, position)
+	}
+	return positions, rows.Err()
+}
+
+// ListClosedTrades functions returns a page of closed positions of the profile closed within given period, newest first
+func (repo *TradingRepository) ListClosedTrades(ctx context.Context, filter *model.ClosedTradeFilter) ([]*model.Position, error) {
+	query := "SELECT " + positionColumns + " FROM trading.trading WHERE status=$1 AND profile_id=$2"
+	args := []interface{}{model.PositionStatusClosed, filter.ProfileID}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		query += fmt.Sprintf(" AND closed_at>=$%d", len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		query += fmt.Sprintf(" AND closed_at<$%d", len(args))
+	}
+	if filter.Cursor != uuid.Nil {
+		args = append(args, filter.Cursor)
+		query += fmt.Sprintf(" AND (closed_at, id) < (SELECT closed_at, id FROM trading.trading WHERE id=$%d)", len(args))
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY closed_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := repo.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*model.Position
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan(): %w", err)
+		}
+		positions = append(positions, position)
+	}
+	return positions, rows.Err()
+}
+
+// GetMonthlyVolume method returns trading volume of the profile since given time
+// Volume is a sum of notional of positions opened and closed since then
+func (repo *TradingRepository) GetMonthlyVolume(ctx context.Context, profileID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	var volume decimal.Decimal
+	err := repo.pool.QueryRow(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN opened_at>=$2 THEN share_price*shares_amount ELSE 0 END), 0)
+		+ COALESCE(SUM(CASE WHEN status=$3 AND closed_at>=$2 THEN exit_price*shares_amount ELSE 0 END), 0)
+		FROM trading.trading WHERE profile_id=$1 AND (opened_at>=$2 OR closed_at>=$2)`,
+		profileID, since, model.PositionStatusClosed).Scan(&volume)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("QueryRow: %w", err)
+	}
+	return volume, nil
+}
+
+// scanPosition function scans a row of positionColumns into position
+func scanPosition(row pgx.Row) (*model.Position, error) {
+	position := &model.Position{}
+	err := row.Scan(
+		&position.ID, &position.ProfileID, &position.IsLong, &position.ShareName, &position.SharePrice, &position.Total, &position.ShareAmount, &position.StopLoss, &position.TakeProfit,
+		&position.Status, &position.ExitPrice, &position.RealizedPnL, &position.CloseReason, &position.OpenedAt, &position.ClosedAt,
+		&position.TrailingStopType, &position.TrailingStopDistance, &position.ParentID, &position.Leverage, &position.ExpiresAt, &position.OpenFee, &position.CloseFee, &position.Version)
+	if err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+// lockPosition function locks the row of the position until the end of transaction and checks that it is in given status and of given version
+// Row lock makes concurrent changes of the position wait, so only one of them sees the version it has read
+func lockPosition(ctx context.Context, tx pgx.Tx, positionID uuid.UUID, status model.PositionStatus, version int64) error {
+	var currentStatus model.PositionStatus
+	var currentVersion int64
+	err := tx.QueryRow(ctx, "SELECT status, version FROM trading.trading WHERE id=$1 FOR UPDATE", positionID).Scan(&currentStatus, &currentVersion)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("QueryRow: %w", err)
+	}
+	if currentStatus == model.PositionStatusClosed {
+		return fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotOpen)
+	}
+	if currentStatus != status || currentVersion != version {
+		return fmt.Errorf("position %v is %s of version %d, expected %s of version %d: %w",
+			positionID, currentStatus, currentVersion, status, version, model.ErrPositionConflict)
+	}
+	return nil
+}
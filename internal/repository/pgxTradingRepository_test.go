@@ -1 +1,88 @@
 package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// newTestSaga function stores a new pending saga of given type with the position
+func newTestSaga(t *testing.T, repo *TradingRepository, sagaType model.SagaType, position *model.Position) *model.Saga {
+	now := time.Now()
+	saga := &model.Saga{ID: uuid.New(), Type: sagaType, Step: model.SagaStepStarted, Status: model.SagaStatusPending, Position: position, CreatedAt: now, UpdatedAt: now}
+	err := repo.CreateSaga(context.Background(), saga)
+	if err != nil {
+		t.Fatalf("CreateSaga: %v", err)
+	}
+	return saga
+}
+
+func TestStartClosingPositionConcurrently(t *testing.T) {
+	repo := NewTradingRepository(requirePool(t))
+	ctx := context.Background()
+	position := &model.Position{ID: uuid.New(), ProfileID: uuid.New(), IsLong: true, ShareName: "AAPL", SharePrice: decimal.NewFromInt(30),
+		Total: decimal.NewFromInt(90), ShareAmount: decimal.NewFromInt(3), Leverage: decimal.NewFromInt(1), OpenedAt: time.Now()}
+	saga := newTestSaga(t, repo, model.SagaOpenPosition, position)
+	saga.Step = model.SagaStepPositionCreated
+	err := repo.CreatePosition(ctx, saga)
+	if err != nil {
+		t.Fatalf("CreatePosition: %v", err)
+	}
+
+	const closings = 2
+	sagas := make([]*model.Saga, closings)
+	for i := range sagas {
+		read := *position
+		sagas[i] = newTestSaga(t, repo, model.SagaClosePosition, &read)
+		sagas[i].Step = model.SagaStepPositionClosing
+	}
+	errs := make([]error, closings)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range sagas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = repo.StartClosingPosition(ctx, sagas[i])
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil && winner == -1:
+			winner = i
+		case err == nil:
+			t.Errorf("closings %d and %d both succeeded", winner, i)
+		case !errors.Is(err, model.ErrPositionConflict):
+			t.Errorf("closing %d returned %v, want %v", i, err, model.ErrPositionConflict)
+		}
+	}
+	if winner == -1 {
+		t.Fatalf("no closing succeeded: %v", errs)
+	}
+
+	closed := sagas[winner]
+	closed.Position.Version++
+	closed.Step = model.SagaStepPositionClosed
+	err = repo.ClosePosition(ctx, closed)
+	if err != nil {
+		t.Fatalf("ClosePosition: %v", err)
+	}
+	stored, err := repo.GetPositionByID(ctx, position.ID)
+	if err != nil {
+		t.Fatalf("GetPositionByID: %v", err)
+	}
+	if stored.Status != model.PositionStatusClosed {
+		t.Errorf("position is %v, want %v", stored.Status, model.PositionStatusClosed)
+	}
+}
@@ -4,36 +4,291 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	proto "github.com/eugenshima/price-service/proto"
 	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// constants of price hub
+const (
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = 10 * time.Second
+	priceWaitTimeout    = 5 * time.Second
+	maxPriceAge         = 10 * time.Second
+	subscriberBuffer    = 16
 )
 
-// PriceServiceClient struct ....
+// PriceServiceClient struct represents a price hub: it keeps one persistent stream to price service
+// for the set of all watched shares and fans its ticks out to any number of subscribers
 type PriceServiceClient struct {
-	client proto.PriceServiceClient
+	client        proto.PriceServiceClient
+	mu            sync.Mutex
+	shares        map[string]int
+	streamed      map[string]bool
+	prices        map[string]*cachedPrice
+	subscribers   map[uuid.UUID]*priceSubscriber
+	cancelStream  context.CancelFunc
+	sharesChanged chan struct{}
+}
+
+// cachedPrice struct represents the latest price of a share and the time it was received
+type cachedPrice struct {
+	share      *model.Share
+	receivedAt time.Time
+}
+
+// priceSubscriber struct represents a consumer of price hub ticks
+type priceSubscriber struct {
+	shares map[string]bool
+	ch     chan []*model.Share
 }
 
 // NewPriceServiceClient creates a new PriceServiceClient
 func NewPriceServiceClient(client proto.PriceServiceClient) *PriceServiceClient {
-	return &PriceServiceClient{client: client}
+	return &PriceServiceClient{
+		client:        client,
+		shares:        make(map[string]int),
+		streamed:      make(map[string]bool),
+		prices:        make(map[string]*cachedPrice),
+		subscribers:   make(map[uuid.UUID]*priceSubscriber),
+		sharesChanged: make(chan struct{}, 1),
+	}
 }
 
-// AddSubscriber method adds a subscriber to the list of subscribers
-func (c *PriceServiceClient) AddSubscriber(ctx context.Context, selectedShares []string) (*model.Share, error) {
+// Run method keeps the stream to price service opened until context is done
+// Stream is reopened when new shares are added and reconnected with exponential backoff when price service drops it
+func (c *PriceServiceClient) Run(ctx context.Context) {
+	backoff := minReconnectBackoff
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		selectedShares := c.startStream(cancel)
+		if len(selectedShares) == 0 {
+			cancel()
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.sharesChanged:
+				continue
+			}
+		}
+		received, err := c.stream(streamCtx, selectedShares)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = minReconnectBackoff
+		}
+		if err == nil {
+			// stream was reopened because of new shares
+			continue
+		}
+		// prices received before the stream was dropped are outdated and must not be used until it is reconnected
+		c.clearPrices()
+		logrus.WithFields(logrus.Fields{"selectedShares": selectedShares, "backoff": backoff}).Errorf("price stream: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// startStream method returns all watched shares and remembers them as streamed along with cancel function of the stream
+func (c *PriceServiceClient) startStream(cancel context.CancelFunc) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelStream = cancel
+	c.streamed = make(map[string]bool, len(c.shares))
+	selectedShares := make([]string, 0, len(c.shares))
+	for shareName := range c.shares {
+		c.streamed[shareName] = true
+		selectedShares = append(selectedShares, shareName)
+	}
+	return selectedShares
+}
+
+// stream method reads the stream of given shares and dispatches its ticks, returns nil error if stream was canceled for resubscribe
+func (c *PriceServiceClient) stream(ctx context.Context, selectedShares []string) (received bool, err error) {
 	stream, err := c.client.Subscribe(ctx, &proto.SubscribeRequest{ShareName: selectedShares})
 	if err != nil {
-		return nil, fmt.Errorf("subscribe: %w", err)
+		return false, fmt.Errorf("subscribe: %w", err)
 	}
-	shares, err := stream.Recv()
-	if err != nil {
-		return nil, fmt.Errorf("recv: %w", err)
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return received, nil
+			}
+			return received, fmt.Errorf("recv: %w", err)
+		}
+		received = true
+		shares := make([]*model.Share, 0, len(response.Shares))
+		for _, share := range response.Shares {
+			shares = append(shares, &model.Share{ShareName: share.ShareName, SharePrice: decimal.NewFromFloat(share.SharePrice)})
+		}
+		c.dispatch(shares)
 	}
+}
 
-	share := &model.Share{
-		ShareName:  shares.Shares[0].ShareName,
-		SharePrice: shares.Shares[0].SharePrice,
+// dispatch method stores latest prices and sends them to interested subscribers
+func (c *PriceServiceClient) dispatch(shares []*model.Share) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, share := range shares {
+		if c.shares[share.ShareName] > 0 {
+			c.prices[share.ShareName] = &cachedPrice{share: share, receivedAt: now}
+		}
 	}
+	for subscriberID, subscriber := range c.subscribers {
+		selected := make([]*model.Share, 0, len(shares))
+		for _, share := range shares {
+			if subscriber.shares[share.ShareName] {
+				selected = append(selected, share)
+			}
+		}
+		if len(selected) == 0 {
+			continue
+		}
+		select {
+		case subscriber.ch <- selected:
+		default:
+			logrus.WithFields(logrus.Fields{"SubscriberID": subscriberID}).Warn("price subscriber is too slow, tick dropped")
+		}
+	}
+}
 
+// clearPrices method forgets all the latest prices
+func (c *PriceServiceClient) clearPrices() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prices = make(map[string]*cachedPrice)
+}
+
+// AddShares method starts watching given shares, every call must be paired with RemoveShares
+func (c *PriceServiceClient) AddShares(shareNames ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addShares(shareNames)
+}
+
+// RemoveShares method stops watching given shares
+// Removed shares are dropped from the price service stream the next time it is reopened
+func (c *PriceServiceClient) RemoveShares(shareNames ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeShares(shareNames)
+}
+
+// addShares method increments watchers of given shares and reopens the stream if some of them are not streamed yet, mu must be held
+func (c *PriceServiceClient) addShares(shareNames []string) {
+	resubscribe := false
+	for _, shareName := range shareNames {
+		c.shares[shareName]++
+		if !c.streamed[shareName] {
+			resubscribe = true
+		}
+	}
+	if !resubscribe {
+		return
+	}
+	if c.cancelStream != nil {
+		c.cancelStream()
+	}
+	select {
+	case c.sharesChanged <- struct{}{}:
+	default:
+	}
+}
+
+// removeShares method decrements watchers of given shares, mu must be held
+func (c *PriceServiceClient) removeShares(shareNames []string) {
+	for _, shareName := range shareNames {
+		c.shares[shareName]--
+		if c.shares[shareName] <= 0 {
+			delete(c.shares, shareName)
+			delete(c.prices, shareName)
+		}
+	}
+}
+
+// SubscribeShares method streams prices of selected shares until context is done
+func (c *PriceServiceClient) SubscribeShares(ctx context.Context, selectedShares []string) (<-chan []*model.Share, error) {
+	subscriber := &priceSubscriber{
+		shares: make(map[string]bool, len(selectedShares)),
+		ch:     make(chan []*model.Share, subscriberBuffer),
+	}
+	for _, shareName := range selectedShares {
+		subscriber.shares[shareName] = true
+	}
+	subscriberID := uuid.New()
+
+	c.mu.Lock()
+	c.subscribers[subscriberID] = subscriber
+	c.addShares(selectedShares)
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.subscribers, subscriberID)
+		c.removeShares(selectedShares)
+		close(subscriber.ch)
+	}()
+	return subscriber.ch, nil
+}
+
+// AddSubscriber method returns the latest price of the first of selected shares
+// Price of a watched share is taken from the hub if it was received during maxPriceAge,
+// otherwise it is requested from price service by a separate stream, so the hub stream of other subscribers isn't reopened
+func (c *PriceServiceClient) AddSubscriber(ctx context.Context, selectedShares []string) (*model.Share, error) {
+	if len(selectedShares) == 0 {
+		return nil, fmt.Errorf("no shares selected")
+	}
+	shareName := selectedShares[0]
+	c.mu.Lock()
+	price, ok := c.prices[shareName]
+	c.mu.Unlock()
+	if ok && time.Since(price.receivedAt) <= maxPriceAge {
+		return price.share, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, priceWaitTimeout)
+	defer cancel()
+	share, err := c.fetchPrice(waitCtx, shareName)
+	if err != nil {
+		return nil, fmt.Errorf("price of share %s is unavailable: %w", shareName, err)
+	}
 	return share, nil
 }
+
+// fetchPrice method opens a stream of one share and returns its first tick
+func (c *PriceServiceClient) fetchPrice(ctx context.Context, shareName string) (*model.Share, error) {
+	stream, err := c.client.Subscribe(ctx, &proto.SubscribeRequest{ShareName: []string{shareName}})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("recv: %w", err)
+		}
+		for _, share := range response.Shares {
+			if share.ShareName == shareName {
+				return &model.Share{ShareName: share.ShareName, SharePrice: decimal.NewFromFloat(share.SharePrice)}, nil
+			}
+		}
+	}
+}
@@ -1,2 +1,143 @@
 // Package repository contains methods to communicate with postgres and gRPC servers
 package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	proto "github.com/eugenshima/price-service/proto"
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+// fakePriceService struct is a price service which sends one tick of fixed prices to every stream
+type fakePriceService struct {
+	mu       sync.Mutex
+	prices   map[string]float64
+	requests [][]string
+	err      error
+}
+
+// Subscribe method records requested shares and returns a stream of one tick
+func (f *fakePriceService) Subscribe(ctx context.Context, in *proto.SubscribeRequest, _ ...grpc.CallOption) (proto.PriceService_SubscribeClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, in.ShareName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	response := &proto.SubscribeResponse{}
+	for _, shareName := range in.ShareName {
+		if price, ok := f.prices[shareName]; ok {
+			response.Shares = append(response.Shares, &proto.Shares{ShareName: shareName, SharePrice: price})
+		}
+	}
+	return &fakePriceStream{ctx: ctx, responses: []*proto.SubscribeResponse{response}}, nil
+}
+
+// requestCount method returns the number of opened streams
+func (f *fakePriceService) requestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+// fakePriceStream struct returns given responses and then blocks until its context is done
+type fakePriceStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	responses []*proto.SubscribeResponse
+}
+
+// Recv method returns the next response
+func (s *fakePriceStream) Recv() (*proto.SubscribeResponse, error) {
+	if len(s.responses) > 0 {
+		response := s.responses[0]
+		s.responses = s.responses[1:]
+		return response, nil
+	}
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func TestAddSubscriberUsesFreshPrice(t *testing.T) {
+	priceService := &fakePriceService{prices: map[string]float64{"AAPL": 101}}
+	client := NewPriceServiceClient(priceService)
+	client.AddShares("AAPL")
+	client.dispatch([]*model.Share{{ShareName: "AAPL", SharePrice: decimal.NewFromInt(100)}})
+
+	share, err := client.AddSubscriber(context.Background(), []string{"AAPL"})
+	if err != nil {
+		t.Fatalf("AddSubscriber: %v", err)
+	}
+	if !share.SharePrice.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("price is %v, want cached 100", share.SharePrice)
+	}
+	if priceService.requestCount() != 0 {
+		t.Errorf("price service was requested %d times, want 0", priceService.requestCount())
+	}
+}
+
+func TestAddSubscriberRefreshesStalePrice(t *testing.T) {
+	priceService := &fakePriceService{prices: map[string]float64{"AAPL": 101}}
+	client := NewPriceServiceClient(priceService)
+	client.AddShares("AAPL")
+	client.prices["AAPL"] = &cachedPrice{
+		share:      &model.Share{ShareName: "AAPL", SharePrice: decimal.NewFromInt(100)},
+		receivedAt: time.Now().Add(-2 * maxPriceAge),
+	}
+
+	share, err := client.AddSubscriber(context.Background(), []string{"AAPL"})
+	if err != nil {
+		t.Fatalf("AddSubscriber: %v", err)
+	}
+	if !share.SharePrice.Equal(decimal.NewFromInt(101)) {
+		t.Errorf("price is %v, want fresh 101", share.SharePrice)
+	}
+}
+
+func TestAddSubscriberDoesNotReopenHubStream(t *testing.T) {
+	priceService := &fakePriceService{prices: map[string]float64{"TSLA": 200}}
+	client := NewPriceServiceClient(priceService)
+	canceled := false
+	client.cancelStream = func() { canceled = true }
+
+	share, err := client.AddSubscriber(context.Background(), []string{"TSLA"})
+	if err != nil {
+		t.Fatalf("AddSubscriber: %v", err)
+	}
+	if !share.SharePrice.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("price is %v, want 200", share.SharePrice)
+	}
+	if canceled {
+		t.Error("hub stream was reopened for a one-off price lookup")
+	}
+	if len(client.shares) != 0 {
+		t.Errorf("watched shares are %v, want none", client.shares)
+	}
+}
+
+func TestAddSubscriberPriceUnavailable(t *testing.T) {
+	priceService := &fakePriceService{err: errors.New("connection refused")}
+	client := NewPriceServiceClient(priceService)
+
+	_, err := client.AddSubscriber(context.Background(), []string{"AAPL"})
+	if err == nil {
+		t.Fatal("AddSubscriber returned no error when price service is down")
+	}
+}
+
+func TestClearPrices(t *testing.T) {
+	client := NewPriceServiceClient(&fakePriceService{})
+	client.AddShares("AAPL")
+	client.dispatch([]*model.Share{{ShareName: "AAPL", SharePrice: decimal.NewFromInt(100)}})
+	client.clearPrices()
+	if len(client.prices) != 0 {
+		t.Errorf("prices are %v after clearPrices, want none", client.prices)
+	}
+}
@@ -0,0 +1,95 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ExpireOrdersAndPositions method expires GTD and DAY orders, closes positions with expiry time
+// and purges expired idempotency keys with given interval until context is done
+func (s *TradingService) ExpireOrdersAndPositions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.expireOrders(ctx, now)
+			s.closeExpiredPositions(ctx, now)
+			s.purgeIdempotencyKeys(ctx, now)
+		}
+	}
+}
+
+// expireOrders method expires pending orders whose expiry time has come
+func (s *TradingService) expireOrders(ctx context.Context, now time.Time) {
+	for _, order := range s.getPendingOrders() {
+		if !order.IsExpired(now) {
+			continue
+		}
+		// order is taken from the book first, so matcher can't fill it while it is expiring
+		if !s.takeOrderFromBook(order.ID) {
+			continue
+		}
+		err := s.expireOrder(ctx, order)
+		if err != nil {
+			s.addOrderToBook(order)
+			logrus.WithFields(logrus.Fields{"OrderID": order.ID}).Errorf("expireOrder: %v", err)
+		}
+	}
+}
+
+// expireOrder method moves pending order to expired status together with OrderExpired event
+// Inactive exit orders of expired entry order are canceled
+func (s *TradingService) expireOrder(ctx context.Context, order *model.Order) error {
+	order.Status = model.OrderStatusExpired
+	order.UpdatedAt = time.Now()
+	event, err := newOutboxEvent(model.EventOrderExpired, &model.Position{ID: order.PositionID, ProfileID: order.ProfileID}, order)
+	if err != nil {
+		order.Status = model.OrderStatusPending
+		return fmt.Errorf("newOutboxEvent: %w", err)
+	}
+	err = s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusPending, event)
+	if err != nil {
+		order.Status = model.OrderStatusPending
+		return fmt.Errorf("UpdateOrderStatus: %w", err)
+	}
+	if !order.IsExit() {
+		s.finishExitOrders(ctx, order)
+	}
+	logrus.WithFields(logrus.Fields{"OrderID": order.ID, "TimeInForce": order.TimeInForce, "ExpiresAt": order.ExpiresAt}).Info("order expired")
+	return nil
+}
+
+// closeExpiredPositions method closes opened positions whose expiry time has come by current share price
+// Margin of closed position is released to the balance by close saga
+func (s *TradingService) closeExpiredPositions(ctx context.Context, now time.Time) {
+	prices := make(map[string]decimal.Decimal)
+	for _, openedPosition := range s.getOpenedPositions() {
+		if openedPosition.ExpiresAt == nil || now.Before(*openedPosition.ExpiresAt) {
+			continue
+		}
+		price, ok := prices[openedPosition.ShareName]
+		if !ok {
+			var err error
+			price, err = s.getSharePrice(ctx, openedPosition.ShareName)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"ShareName": openedPosition.ShareName}).Errorf("getSharePrice: %v", err)
+				continue
+			}
+			prices[openedPosition.ShareName] = price
+		}
+		err := s.closeTriggeredPosition(ctx, openedPosition, model.CloseReasonExpired, price)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"PositionID": openedPosition.PositionID}).Errorf("closeTriggeredPosition: %v", err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// calculateFee method calculates commission of a trade of given notional by commission schedule of the share
+// Monthly trading volume of the profile is only fetched if the schedule is tiered
+func (s *TradingService) calculateFee(ctx context.Context, profileID uuid.UUID, shareName string, notional decimal.Decimal) (decimal.Decimal, error) {
+	schedule := s.commissions.Schedule(shareName)
+	volume := decimal.Zero
+	if schedule.IsTiered() {
+		var err error
+		volume, err = s.rps.GetMonthlyVolume(ctx, profileID, monthStart(time.Now(), s.schedule.Location))
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("GetMonthlyVolume: %w", err)
+		}
+	}
+	return s.rounding.Money(schedule.Fee(notional, volume)), nil
+}
+
+// chargeCloseFee function deducts commission of closing from settlement of the position
+// Commission is limited by settlement, so closing never debits the balance
+func chargeCloseFee(settlement, fee decimal.Decimal) (charged, netSettlement decimal.Decimal) {
+	if fee.GreaterThan(settlement) {
+		return settlement, decimal.Zero
+	}
+	return fee, settlement.Sub(fee)
+}
+
+// newProfitAndLoss function creates realized profit and loss of the closed position before and after commissions
+func newProfitAndLoss(position *model.Position) *model.ProfitAndLoss {
+	profitAndLoss := &model.ProfitAndLoss{
+		Gross:      position.RealizedPnL,
+		Net:        position.NetPnL(),
+		Commission: position.OpenFee.Add(position.CloseFee),
+	}
+	if position.Total.IsPositive() {
+		profitAndLoss.GrossPercent = position.RealizedPnL.Div(position.Total).Mul(hundred).Round(model.PercentScale)
+		profitAndLoss.NetPercent = profitAndLoss.Net.Div(position.Total.Add(position.OpenFee)).Mul(hundred).Round(model.PercentScale)
+	}
+	return profitAndLoss
+}
+
+// monthStart function returns the beginning of the calendar month of given time in given location
+func monthStart(now time.Time, location *time.Location) time.Time {
+	local := now.In(location)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, location)
+}
@@ -0,0 +1,56 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+)
+
+func TestChargeCloseFee(t *testing.T) {
+	tests := []struct {
+		name          string
+		settlement    string
+		fee           string
+		charged       string
+		netSettlement string
+	}{
+		{name: "fee is less than settlement", settlement: "100", fee: "2", charged: "2", netSettlement: "98"},
+		{name: "fee is more than settlement", settlement: "1", fee: "2", charged: "1", netSettlement: "0"},
+		{name: "nothing is settled", settlement: "0", fee: "2", charged: "0", netSettlement: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charged, netSettlement := chargeCloseFee(dec(tt.settlement), dec(tt.fee))
+			if !charged.Equal(dec(tt.charged)) || !netSettlement.Equal(dec(tt.netSettlement)) {
+				t.Errorf("chargeCloseFee = (%v, %v), want (%v, %v)", charged, netSettlement, tt.charged, tt.netSettlement)
+			}
+		})
+	}
+}
+
+func TestNewProfitAndLoss(t *testing.T) {
+	position := &model.Position{Total: dec("100"), RealizedPnL: dec("20"), OpenFee: dec("1"), CloseFee: dec("1.2")}
+	profitAndLoss := newProfitAndLoss(position)
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "gross", value: profitAndLoss.Gross.String(), want: "20"},
+		{name: "net", value: profitAndLoss.Net.String(), want: "17.8"},
+		{name: "commission", value: profitAndLoss.Commission.String(), want: "2.2"},
+		{name: "gross percent", value: profitAndLoss.GrossPercent.String(), want: "20"},
+		{name: "net percent", value: profitAndLoss.NetPercent.String(), want: "17.62"},
+	}
+	for _, tt := range tests {
+		if !dec(tt.value).Equal(dec(tt.want)) {
+			t.Errorf("%s is %v, want %v", tt.name, tt.value, tt.want)
+		}
+	}
+
+	profitAndLoss = newProfitAndLoss(&model.Position{RealizedPnL: dec("0"), OpenFee: dec("1")})
+	if !profitAndLoss.GrossPercent.IsZero() || !profitAndLoss.NetPercent.IsZero() {
+		t.Errorf("percents of position with zero total are %v and %v, want zero", profitAndLoss.GrossPercent, profitAndLoss.NetPercent)
+	}
+}
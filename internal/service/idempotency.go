@@ -0,0 +1,138 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// idempotencyLease is a time during which pending idempotency key is owned by the request which claimed it
+// Lease is longer than staleness of sagas, so saga of a crashed request is resumed before its key is reclaimed by a retry
+const idempotencyLease = 2 * sagaStaleAfter
+
+// runIdempotent method runs the operation once per idempotency key of the profile and stores its response
+// Replay of the request within retention window gets the stored response unmarshaled into response without running the operation again
+// Key of failed operation is freed, so the request can be retried with it, empty key means that the request is not protected
+// Operation must fail only before it changes any state, failures after its point of no return are finished by sagas and reported as success
+// Response is stored with pending key before the operation is run, so a retry which reclaims the key of a crashed request
+// gets it back and run is told that the operation may have been done already
+func (s *TradingService) runIdempotent(ctx context.Context, profileID uuid.UUID, key string, operation model.IdempotencyOperation, request, response interface{}, run func(reclaimed bool) error) error {
+	if key == "" {
+		return run(false)
+	}
+	requestHash, err := hashRequest(request)
+	if err != nil {
+		return fmt.Errorf("hashRequest: %w", err)
+	}
+	now := time.Now()
+	record := &model.IdempotencyRecord{
+		ProfileID:   profileID,
+		Key:         key,
+		Operation:   operation,
+		RequestHash: requestHash,
+		Status:      model.IdempotencyStatusPending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.idempotencyTTL),
+		LockedUntil: now.Add(idempotencyLease),
+	}
+	record.Response, err = json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	claimed, err := s.rps.ClaimIdempotencyKey(ctx, record)
+	if err != nil {
+		return fmt.Errorf("ClaimIdempotencyKey: %w", err)
+	}
+	reclaimed := false
+	if !claimed {
+		reclaimed, err = s.replayIdempotent(ctx, record, response)
+		if err != nil {
+			return fmt.Errorf("replayIdempotent: %w", err)
+		}
+		if !reclaimed {
+			return nil
+		}
+	}
+
+	err = run(reclaimed)
+	if err != nil {
+		deleteErr := s.rps.DeleteIdempotencyKey(ctx, profileID, key, operation)
+		if deleteErr != nil {
+			logrus.WithFields(logrus.Fields{"Key": key, "Operation": operation}).Errorf("DeleteIdempotencyKey: %v", deleteErr)
+		}
+		return err
+	}
+	record.Response, err = json.Marshal(response)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"Key": key, "Operation": operation}).Errorf("Marshal: %v", err)
+		return nil
+	}
+	record.Status = model.IdempotencyStatusCompleted
+	err = s.rps.CompleteIdempotencyKey(ctx, record)
+	if err != nil {
+		// operation is done, the key stays pending until it expires, so the request is not repeated
+		logrus.WithFields(logrus.Fields{"Key": key, "Operation": operation}).Errorf("CompleteIdempotencyKey: %v", err)
+	}
+	return nil
+}
+
+// replayIdempotent method unmarshals stored response of the request which already took the key
+// Pending key whose lease is over is reclaimed, true is returned then and the operation must be run again
+func (s *TradingService) replayIdempotent(ctx context.Context, record *model.IdempotencyRecord, response interface{}) (bool, error) {
+	stored, err := s.rps.GetIdempotencyRecord(ctx, record.ProfileID, record.Key, record.Operation)
+	if err != nil {
+		return false, fmt.Errorf("GetIdempotencyRecord: %w", err)
+	}
+	if stored.RequestHash != record.RequestHash {
+		return false, fmt.Errorf("%w: %s", model.ErrIdempotencyKeyReused, record.Key)
+	}
+	if stored.Status != model.IdempotencyStatusCompleted {
+		if stored.LockedUntil.After(record.CreatedAt) {
+			return false, fmt.Errorf("%w: %s", model.ErrIdempotencyKeyInProgress, record.Key)
+		}
+		reclaimed, err := s.rps.ReclaimIdempotencyKey(ctx, record)
+		if err != nil {
+			return false, fmt.Errorf("ReclaimIdempotencyKey: %w", err)
+		}
+		if !reclaimed {
+			return false, fmt.Errorf("%w: %s", model.ErrIdempotencyKeyInProgress, record.Key)
+		}
+		logrus.WithFields(logrus.Fields{"Key": record.Key, "Operation": record.Operation}).Warn("stale idempotency key reclaimed")
+	}
+	err = json.Unmarshal(stored.Response, response)
+	if err != nil {
+		return false, fmt.Errorf("Unmarshal: %w", err)
+	}
+	if stored.Status != model.IdempotencyStatusCompleted {
+		return true, nil
+	}
+	logrus.WithFields(logrus.Fields{"Key": record.Key, "Operation": record.Operation}).Info("idempotent request replayed")
+	return false, nil
+}
+
+// purgeIdempotencyKeys method deletes idempotency keys whose retention window is over
+func (s *TradingService) purgeIdempotencyKeys(ctx context.Context, now time.Time) {
+	_, err := s.rps.DeleteExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		logrus.Errorf("DeleteExpiredIdempotencyKeys: %v", err)
+	}
+}
+
+// hashRequest function returns SHA-256 of JSON representation of the request
+func hashRequest(request interface{}) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("Marshal: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,127 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func TestOpenPositionReplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   string
+		wantErr error
+	}{
+		{name: "same request", total: "90"},
+		{name: "different request", total: "60", wantErr: model.ErrIdempotencyKeyReused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			rps := newMemoryRepository()
+			balance := newMemoryBalance(profileID, dec("1000"))
+			s, _ := newMemoryService(rps, balance, "AAPL", dec("30"), 0)
+			first := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true, Total: dec("90")}
+			err := s.OpenPosition(context.Background(), first, "key")
+			if err != nil {
+				t.Fatalf("OpenPosition: %v", err)
+			}
+
+			retry := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true, Total: dec(tt.total)}
+			err = s.OpenPosition(context.Background(), retry, "key")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenPosition of retry returned %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (retry.ID != first.ID || !retry.Total.Equal(first.Total)) {
+				t.Errorf("retry got position %v with total %v, want %v with total %v", retry.ID, retry.Total, first.ID, first.Total)
+			}
+			if len(rps.positions) != 1 {
+				t.Errorf("%d positions are opened, want 1", len(rps.positions))
+			}
+			if got := balance.balance(profileID); !got.Equal(dec("910")) {
+				t.Errorf("balance is %v, want 910", got)
+			}
+		})
+	}
+}
+
+func TestOpenPositionWithPendingKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		lockedFor   time.Duration
+		opened      bool
+		wantErr     error
+		wantBalance string
+	}{
+		{name: "lease is not over", lockedFor: time.Minute, wantErr: model.ErrIdempotencyKeyInProgress, wantBalance: "1000"},
+		{name: "stale key of request which did not open position", lockedFor: -time.Second, wantBalance: "910"},
+		{name: "stale key of request which opened position", lockedFor: -time.Second, opened: true, wantBalance: "910"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			rps := newMemoryRepository()
+			balance := newMemoryBalance(profileID, dec("1000"))
+			s, _ := newMemoryService(rps, balance, "AAPL", dec("30"), 0)
+
+			// key is left pending by a request which crashed after claiming it
+			crashed := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true, Total: dec("90")}
+			request := *crashed
+			request.ID = uuid.Nil
+			requestHash, err := hashRequest(&request)
+			if err != nil {
+				t.Fatalf("hashRequest: %v", err)
+			}
+			response, err := json.Marshal(crashed)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			now := time.Now()
+			_, err = rps.ClaimIdempotencyKey(context.Background(), &model.IdempotencyRecord{
+				ProfileID: profileID, Key: "key", Operation: model.IdempotencyOpenPosition, RequestHash: requestHash,
+				Status: model.IdempotencyStatusPending, Response: response, CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: now.Add(tt.lockedFor),
+			})
+			if err != nil {
+				t.Fatalf("ClaimIdempotencyKey: %v", err)
+			}
+			if tt.opened {
+				err = s.openPosition(context.Background(), crashed)
+				if err != nil {
+					t.Fatalf("openPosition: %v", err)
+				}
+			}
+
+			retry := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true, Total: dec("90")}
+			err = s.OpenPosition(context.Background(), retry, "key")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenPosition of retry returned %v, want %v", err, tt.wantErr)
+			}
+			if got := balance.balance(profileID); !got.Equal(dec(tt.wantBalance)) {
+				t.Errorf("balance is %v, want %v", got, tt.wantBalance)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if retry.ID != crashed.ID {
+				t.Errorf("retry got position %v, want position %v of the crashed request", retry.ID, crashed.ID)
+			}
+			if len(rps.positions) != 1 {
+				t.Errorf("%d positions are opened, want 1", len(rps.positions))
+			}
+			record, err := rps.GetIdempotencyRecord(context.Background(), profileID, "key", model.IdempotencyOpenPosition)
+			if err != nil {
+				t.Fatalf("GetIdempotencyRecord: %v", err)
+			}
+			if record.Status != model.IdempotencyStatusCompleted {
+				t.Errorf("key is %v after retry, want %v", record.Status, model.IdempotencyStatusCompleted)
+			}
+		})
+	}
+}
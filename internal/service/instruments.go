@@ -0,0 +1,185 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoadInstruments method fills instrument registry with instruments from database
+func (s *TradingService) LoadInstruments(ctx context.Context) error {
+	instruments, err := s.rps.GetInstruments(ctx)
+	if err != nil {
+		return fmt.Errorf("GetInstruments: %w", err)
+	}
+	s.instruments.Mu.Lock()
+	defer s.instruments.Mu.Unlock()
+	s.instruments.Instruments = make(map[string]*model.Instrument, len(instruments))
+	for _, instrument := range instruments {
+		s.instruments.Instruments[instrument.ShareName] = instrument
+	}
+	return nil
+}
+
+// CreateInstrument method validates and stores a new instrument and adds it to instrument registry
+func (s *TradingService) CreateInstrument(ctx context.Context, instrument *model.Instrument) error {
+	err := validateInstrument(instrument)
+	if err != nil {
+		return fmt.Errorf("validateInstrument: %w", err)
+	}
+	instrument.CreatedAt = time.Now()
+	instrument.UpdatedAt = instrument.CreatedAt
+	err = s.rps.CreateInstrument(ctx, instrument)
+	if err != nil {
+		return fmt.Errorf("CreateInstrument: %w", err)
+	}
+	s.setInstrument(instrument)
+	return nil
+}
+
+// UpdateInstrument method replaces trading rules of an existing instrument
+// Opened positions and pending orders are kept, new rules apply to the next trades
+func (s *TradingService) UpdateInstrument(ctx context.Context, instrument *model.Instrument) (*model.Instrument, error) {
+	existing, err := s.GetInstrument(instrument.ShareName)
+	if err != nil {
+		return nil, fmt.Errorf("GetInstrument: %w", err)
+	}
+	err = validateInstrument(instrument)
+	if err != nil {
+		return nil, fmt.Errorf("validateInstrument: %w", err)
+	}
+	instrument.CreatedAt = existing.CreatedAt
+	instrument.UpdatedAt = time.Now()
+	err = s.rps.UpdateInstrument(ctx, instrument)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateInstrument: %w", err)
+	}
+	s.setInstrument(instrument)
+	return instrument, nil
+}
+
+// GetInstrument method returns a copy of the instrument of given share from instrument registry
+func (s *TradingService) GetInstrument(shareName string) (*model.Instrument, error) {
+	s.instruments.Mu.RLock()
+	defer s.instruments.Mu.RUnlock()
+	instrument, ok := s.instruments.Instruments[shareName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrInstrumentNotFound, shareName)
+	}
+	instrumentCopy := *instrument
+	return &instrumentCopy, nil
+}
+
+// ListInstruments method returns instruments sorted by share name, only tradable ones if tradableOnly is set
+func (s *TradingService) ListInstruments(tradableOnly bool) []*model.Instrument {
+	s.instruments.Mu.RLock()
+	defer s.instruments.Mu.RUnlock()
+	instruments := make([]*model.Instrument, 0, len(s.instruments.Instruments))
+	for _, instrument := range s.instruments.Instruments {
+		if tradableOnly && !instrument.Tradable {
+			continue
+		}
+		instrumentCopy := *instrument
+		instruments = append(instruments, &instrumentCopy)
+	}
+	sort.Slice(instruments, func(i, j int) bool {
+		return instruments[i].ShareName < instruments[j].ShareName
+	})
+	return instruments
+}
+
+// GetTradableInstrument method returns the instrument of given share if the share can be traded
+// Shares which are not in instrument registry are traded by default precision without lot and order value limits
+func (s *TradingService) GetTradableInstrument(shareName string) (*model.Instrument, error) {
+	instrument, err := s.GetInstrument(shareName)
+	if errors.Is(err, model.ErrInstrumentNotFound) {
+		return s.defaultInstrument(shareName), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetInstrument: %w", err)
+	}
+	if !instrument.Tradable {
+		return nil, fmt.Errorf("%w: %s", model.ErrInstrumentNotTradable, shareName)
+	}
+	return instrument, nil
+}
+
+// defaultInstrument method returns tradable instrument of the share with default precision
+func (s *TradingService) defaultInstrument(shareName string) *model.Instrument {
+	return &model.Instrument{
+		ShareName:     shareName,
+		TickSize:      s.rounding.Default.TickSize,
+		QuantityScale: s.rounding.Default.AmountScale,
+		Tradable:      true,
+	}
+}
+
+// setInstrument method puts a copy of the instrument into instrument registry
+func (s *TradingService) setInstrument(instrument *model.Instrument) {
+	s.instruments.Mu.Lock()
+	defer s.instruments.Mu.Unlock()
+	instrumentCopy := *instrument
+	s.instruments.Instruments[instrument.ShareName] = &instrumentCopy
+}
+
+// precision method returns precision of the share from instrument registry or default precision for unknown shares
+func (s *TradingService) precision(shareName string) model.Precision {
+	s.instruments.Mu.RLock()
+	defer s.instruments.Mu.RUnlock()
+	if instrument, ok := s.instruments.Instruments[shareName]; ok {
+		return instrument.Precision()
+	}
+	return s.rounding.Default
+}
+
+// validateInstrument function checks trading rules of the instrument
+func validateInstrument(instrument *model.Instrument) error {
+	if instrument.ShareName == "" {
+		return model.NewValidationError("shareName", "share name is required")
+	}
+	if !instrument.TickSize.IsPositive() {
+		return model.NewValidationError("tickSize", "tick size %v must be positive", instrument.TickSize)
+	}
+	if instrument.QuantityScale < 0 {
+		return model.NewValidationError("quantityScale", "quantity scale %v must not be negative", instrument.QuantityScale)
+	}
+	if !instrument.MinLot.IsPositive() {
+		return model.NewValidationError("minLot", "min lot %v must be positive", instrument.MinLot)
+	}
+	if !instrument.MinLot.Equal(instrument.MinLot.Truncate(instrument.QuantityScale)) {
+		return model.NewValidationError("minLot", "min lot %v has more than %v decimal places", instrument.MinLot, instrument.QuantityScale)
+	}
+	if instrument.MinOrderValue.IsNegative() || instrument.MaxOrderValue.IsNegative() {
+		return model.NewValidationError("minOrderValue", "order value limits must not be negative")
+	}
+	if instrument.MaxOrderValue.IsPositive() && instrument.MaxOrderValue.LessThan(instrument.MinOrderValue) {
+		return model.NewValidationError("maxOrderValue", "max order value %v is less than min order value %v", instrument.MaxOrderValue, instrument.MinOrderValue)
+	}
+	return nil
+}
+
+// checkOrderValue function checks that value of shares bought by an order is within limits of the instrument
+func checkOrderValue(instrument *model.Instrument, orderValue decimal.Decimal) error {
+	if orderValue.LessThan(instrument.MinOrderValue) {
+		return model.NewValidationError("total", "order value %v is less than min order value %v of %s", orderValue, instrument.MinOrderValue, instrument.ShareName)
+	}
+	if instrument.MaxOrderValue.IsPositive() && orderValue.GreaterThan(instrument.MaxOrderValue) {
+		return model.NewValidationError("total", "order value %v is greater than max order value %v of %s", orderValue, instrument.MaxOrderValue, instrument.ShareName)
+	}
+	return nil
+}
+
+// checkMinLot function checks that amount of bought shares is not less than min lot of the instrument
+func checkMinLot(instrument *model.Instrument, shareAmount decimal.Decimal) error {
+	if shareAmount.LessThan(instrument.MinLot) {
+		return model.NewValidationError("total", "amount of shares %v is less than min lot %v of %s", shareAmount, instrument.MinLot, instrument.ShareName)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// testInstrument function returns a valid instrument with fractional shares
+func testInstrument() *model.Instrument {
+	return &model.Instrument{
+		ShareName:     "AAPL",
+		TickSize:      dec("0.01"),
+		MinLot:        dec("0.1"),
+		QuantityScale: 2,
+		MinOrderValue: dec("10"),
+		MaxOrderValue: dec("10000"),
+		Tradable:      true,
+	}
+}
+
+func TestValidateInstrument(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(instrument *model.Instrument)
+		valid  bool
+	}{
+		{name: "valid", change: func(*model.Instrument) {}, valid: true},
+		{name: "order value is not limited", change: func(i *model.Instrument) { i.MaxOrderValue = dec("0") }, valid: true},
+		{name: "no share name", change: func(i *model.Instrument) { i.ShareName = "" }},
+		{name: "zero tick size", change: func(i *model.Instrument) { i.TickSize = dec("0") }},
+		{name: "negative quantity scale", change: func(i *model.Instrument) { i.QuantityScale = -1 }},
+		{name: "zero min lot", change: func(i *model.Instrument) { i.MinLot = dec("0") }},
+		{name: "min lot is finer than quantity scale", change: func(i *model.Instrument) { i.MinLot = dec("0.001") }},
+		{name: "negative min order value", change: func(i *model.Instrument) { i.MinOrderValue = dec("-1") }},
+		{name: "max order value is less than min", change: func(i *model.Instrument) { i.MaxOrderValue = dec("5") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instrument := testInstrument()
+			tt.change(instrument)
+			err := validateInstrument(instrument)
+			if tt.valid && err != nil {
+				t.Errorf("validateInstrument: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, model.ErrInvalidArgument) {
+				t.Errorf("validateInstrument returned %v, want invalid argument", err)
+			}
+		})
+	}
+}
+
+func TestCheckOrderValue(t *testing.T) {
+	tests := []struct {
+		orderValue string
+		valid      bool
+	}{
+		{orderValue: "9.99"},
+		{orderValue: "10", valid: true},
+		{orderValue: "10000", valid: true},
+		{orderValue: "10000.01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orderValue, func(t *testing.T) {
+			err := checkOrderValue(testInstrument(), dec(tt.orderValue))
+			if tt.valid != (err == nil) {
+				t.Errorf("checkOrderValue(%v) returned %v, want valid %v", tt.orderValue, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestCheckMinLot(t *testing.T) {
+	tests := []struct {
+		shareAmount string
+		valid       bool
+	}{
+		{shareAmount: "0.09"},
+		{shareAmount: "0.1", valid: true},
+		{shareAmount: "3", valid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shareAmount, func(t *testing.T) {
+			err := checkMinLot(testInstrument(), dec(tt.shareAmount))
+			if tt.valid != (err == nil) {
+				t.Errorf("checkMinLot(%v) returned %v, want valid %v", tt.shareAmount, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestGetTradableInstrument(t *testing.T) {
+	tests := []struct {
+		name      string
+		shareName string
+		tradable  bool
+		want      *model.Instrument
+		wantErr   error
+	}{
+		{name: "registered", shareName: "AAPL", tradable: true, want: &model.Instrument{ShareName: "AAPL", TickSize: dec("0.01"), QuantityScale: 2, Tradable: true}},
+		{name: "registered and not tradable", shareName: "AAPL", wantErr: model.ErrInstrumentNotTradable},
+		{name: "not registered", shareName: "MSFT", want: &model.Instrument{ShareName: "MSFT", TickSize: dec("0.05"), QuantityScale: 4, Tradable: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newMemoryService(newMemoryRepository(), nil, "AAPL", dec("100"), 2)
+			s.rounding = &model.RoundingRules{MoneyScale: 2, Default: model.Precision{TickSize: dec("0.05"), AmountScale: 4}}
+			s.instruments.Instruments["AAPL"].Tradable = tt.tradable
+
+			instrument, err := s.GetTradableInstrument(tt.shareName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTradableInstrument returned %v, want %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if instrument.ShareName != tt.want.ShareName || !instrument.TickSize.Equal(tt.want.TickSize) ||
+				instrument.QuantityScale != tt.want.QuantityScale || instrument.Tradable != tt.want.Tradable {
+				t.Errorf("GetTradableInstrument returned %+v, want %+v", instrument, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenPositionOfUnregisteredInstrument(t *testing.T) {
+	profileID := uuid.New()
+	balance := newMemoryBalance(profileID, dec("1000"))
+	s, prices := newMemoryService(newMemoryRepository(), balance, "AAPL", dec("100"), 2)
+	s.rounding = &model.RoundingRules{MoneyScale: 2, Default: model.Precision{TickSize: dec("0.01"), AmountScale: 4}}
+	prices.setPrice("MSFT", dec("30"))
+	position := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "MSFT", IsLong: true, Total: dec("100")}
+
+	err := s.OpenPosition(context.Background(), position, "")
+	if err != nil {
+		t.Fatalf("OpenPosition: %v", err)
+	}
+	if !position.ShareAmount.Equal(dec("3.3333")) || !position.Total.Equal(dec("100")) {
+		t.Errorf("position has %v shares for %v, want 3.3333 shares for 100", position.ShareAmount, position.Total)
+	}
+	if got := balance.balance(profileID); !got.Equal(dec("900")) {
+		t.Errorf("balance is %v, want 900", got)
+	}
+}
@@ -0,0 +1,238 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// GetMarginLevel method returns margin state of the profile by current share prices
+func (s *TradingService) GetMarginLevel(ctx context.Context, profileID uuid.UUID) (*model.MarginLevel, error) {
+	openedPositions := make([]*model.OpenedPosition, 0)
+	for _, openedPosition := range s.getProfileOpenedPositions(profileID) {
+		openedPositions = append(openedPositions, openedPosition)
+	}
+	prices, err := s.getSharePrices(ctx, openedPositions)
+	if err != nil {
+		return nil, fmt.Errorf("getSharePrices: %w", err)
+	}
+	balance, err := s.balanceRps.GetBalance(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBalance: %w", err)
+	}
+	return calculateMarginLevel(profileID, balance.Balance, openedPositions, prices, s.marginRules, s.rounding), nil
+}
+
+// checkMargins method calculates margin levels of profiles with leveraged positions, notifies profiles about margin calls
+// and liquidates their positions when equity drops below liquidation level
+func (s *TradingService) checkMargins(ctx context.Context) {
+	profiles := make(map[uuid.UUID][]*model.OpenedPosition)
+	leveraged := make(map[uuid.UUID]bool)
+	for _, openedPosition := range s.getOpenedPositions() {
+		profiles[openedPosition.ProfileID] = append(profiles[openedPosition.ProfileID], openedPosition)
+		if isLeveraged(openedPosition) {
+			leveraged[openedPosition.ProfileID] = true
+		}
+	}
+	s.clearMarginCalls(leveraged)
+	for profileID := range leveraged {
+		err := s.checkProfileMargin(ctx, profileID, profiles[profileID])
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"ProfileID": profileID}).Errorf("checkProfileMargin: %v", err)
+		}
+	}
+}
+
+// checkProfileMargin method checks margin level of the profile by current share prices
+func (s *TradingService) checkProfileMargin(ctx context.Context, profileID uuid.UUID, openedPositions []*model.OpenedPosition) error {
+	prices, err := s.getSharePrices(ctx, openedPositions)
+	if err != nil {
+		return fmt.Errorf("getSharePrices: %w", err)
+	}
+	balance, err := s.balanceRps.GetBalance(ctx, profileID)
+	if err != nil {
+		return fmt.Errorf("GetBalance: %w", err)
+	}
+	marginLevel := calculateMarginLevel(profileID, balance.Balance, openedPositions, prices, s.marginRules, s.rounding)
+	if marginLevel.MaintenanceMargin.IsPositive() && marginLevel.Level.LessThan(s.marginRules.LiquidationLevel) {
+		s.liquidate(ctx, marginLevel, openedPositions, prices)
+		return nil
+	}
+	if !marginLevel.MarginCall {
+		s.setMarginCall(profileID, false)
+		return nil
+	}
+	if !s.setMarginCall(profileID, true) {
+		// profile is already notified about this margin call
+		return nil
+	}
+	s.publishPositionUpdate(&model.PositionUpdate{Type: model.PositionUpdateMarginCall, ProfileID: profileID, MarginLevel: marginLevel.Level})
+	event, err := newOutboxEvent(model.EventMarginCall, &model.Position{ProfileID: profileID}, marginLevel)
+	if err != nil {
+		return fmt.Errorf("newOutboxEvent: %w", err)
+	}
+	err = s.rps.CreateOutboxEvents(ctx, event)
+	if err != nil {
+		return fmt.Errorf("CreateOutboxEvents: %w", err)
+	}
+	logrus.WithFields(logrus.Fields{"ProfileID": profileID, "MarginLevel": marginLevel.Level}).Warn("margin call")
+	return nil
+}
+
+// liquidate method closes leveraged positions of the profile starting from the most losing one
+// until equity is back above liquidation level
+func (s *TradingService) liquidate(ctx context.Context, marginLevel *model.MarginLevel, openedPositions []*model.OpenedPosition, prices map[string]decimal.Decimal) {
+	leveraged := make([]*model.OpenedPosition, 0, len(openedPositions))
+	for _, openedPosition := range openedPositions {
+		if isLeveraged(openedPosition) {
+			leveraged = append(leveraged, openedPosition)
+		}
+	}
+	sort.Slice(leveraged, func(i, j int) bool {
+		return openedPositionPnL(leveraged[i], prices[leveraged[i].ShareName]).LessThan(openedPositionPnL(leveraged[j], prices[leveraged[j].ShareName]))
+	})
+	equity := marginLevel.Equity
+	maintenanceMargin := marginLevel.MaintenanceMargin
+	liquidationLevel := s.marginRules.LiquidationLevel.Div(hundred)
+	for _, openedPosition := range leveraged {
+		if maintenanceMargin.IsPositive() && equity.GreaterThanOrEqual(maintenanceMargin.Mul(liquidationLevel)) {
+			break
+		}
+		price := prices[openedPosition.ShareName]
+		err := s.closeTriggeredPosition(ctx, openedPosition, model.CloseReasonLiquidation, price)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"PositionID": openedPosition.PositionID}).Errorf("closeTriggeredPosition: %v", err)
+			continue
+		}
+		// settlement is never negative, so the loss above margin is not taken from equity anymore
+		value := openedPosition.Margin.Add(openedPositionPnL(openedPosition, price))
+		if value.IsNegative() {
+			equity = equity.Sub(value)
+		}
+		maintenanceMargin = maintenanceMargin.Sub(calculateMaintenanceMargin(openedPosition, price, s.marginRules))
+	}
+	s.setMarginCall(marginLevel.ProfileID, false)
+	logrus.WithFields(logrus.Fields{"ProfileID": marginLevel.ProfileID, "MarginLevel": marginLevel.Level}).Warn("positions liquidated")
+}
+
+// getSharePrices method returns current prices of shares of given positions
+func (s *TradingService) getSharePrices(ctx context.Context, openedPositions []*model.OpenedPosition) (map[string]decimal.Decimal, error) {
+	prices := make(map[string]decimal.Decimal)
+	for _, openedPosition := range openedPositions {
+		if _, ok := prices[openedPosition.ShareName]; ok {
+			continue
+		}
+		price, err := s.getSharePrice(ctx, openedPosition.ShareName)
+		if err != nil {
+			return nil, fmt.Errorf("getSharePrice: %w", err)
+		}
+		prices[openedPosition.ShareName] = price
+	}
+	return prices, nil
+}
+
+// setMarginCall method sets margin call state of the profile, returns true if the state was changed
+func (s *TradingService) setMarginCall(profileID uuid.UUID, marginCall bool) bool {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	if s.positionManager.MarginCalls[profileID] == marginCall {
+		return false
+	}
+	if marginCall {
+		s.positionManager.MarginCalls[profileID] = true
+	} else {
+		delete(s.positionManager.MarginCalls, profileID)
+	}
+	return true
+}
+
+// clearMarginCalls method removes margin call state of profiles which have no leveraged positions anymore
+func (s *TradingService) clearMarginCalls(leveraged map[uuid.UUID]bool) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	for profileID := range s.positionManager.MarginCalls {
+		if !leveraged[profileID] {
+			delete(s.positionManager.MarginCalls, profileID)
+		}
+	}
+}
+
+// calculateMarginLevel function calculates margin state of the profile with given balance and opened positions
+func calculateMarginLevel(profileID uuid.UUID, balance decimal.Decimal, openedPositions []*model.OpenedPosition, prices map[string]decimal.Decimal, rules *model.MarginRules, rounding *model.RoundingRules) *model.MarginLevel {
+	equity := balance
+	usedMargin := decimal.Zero
+	maintenanceMargin := decimal.Zero
+	for _, openedPosition := range openedPositions {
+		price := prices[openedPosition.ShareName]
+		equity = equity.Add(openedPosition.Margin).Add(openedPositionPnL(openedPosition, price))
+		usedMargin = usedMargin.Add(openedPosition.Margin)
+		maintenanceMargin = maintenanceMargin.Add(calculateMaintenanceMargin(openedPosition, price, rules))
+	}
+	marginLevel := &model.MarginLevel{
+		ProfileID:         profileID,
+		Balance:           balance,
+		Equity:            rounding.Money(equity),
+		UsedMargin:        rounding.Money(usedMargin),
+		MaintenanceMargin: rounding.Money(maintenanceMargin),
+	}
+	if maintenanceMargin.IsPositive() {
+		marginLevel.Level = equity.Div(maintenanceMargin).Mul(hundred).Round(model.PercentScale)
+		marginLevel.MarginCall = marginLevel.Level.LessThan(rules.MarginCallLevel)
+	}
+	return marginLevel
+}
+
+// calculateMaintenanceMargin function calculates margin required to keep leveraged position opened by current share price
+func calculateMaintenanceMargin(openedPosition *model.OpenedPosition, price decimal.Decimal, rules *model.MarginRules) decimal.Decimal {
+	if !isLeveraged(openedPosition) {
+		return decimal.Zero
+	}
+	return price.Mul(openedPosition.ShareAmount).Mul(rules.MaintenanceMarginRate)
+}
+
+// openedPositionPnL function calculates unrealized PnL of opened position by given share price
+func openedPositionPnL(openedPosition *model.OpenedPosition, price decimal.Decimal) decimal.Decimal {
+	PnL := price.Sub(openedPosition.ShareOpenPrice).Mul(openedPosition.ShareAmount)
+	if !openedPosition.IsLong {
+		return PnL.Neg()
+	}
+	return PnL
+}
+
+// calculateExposure function calculates value of shares bought for given margin with given leverage
+func calculateExposure(margin, leverage decimal.Decimal) decimal.Decimal {
+	return margin.Mul(positionLeverage(leverage))
+}
+
+// positionLeverage function returns leverage of position, positions without leverage have leverage of 1
+func positionLeverage(leverage decimal.Decimal) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	if leverage.LessThan(one) {
+		return one
+	}
+	return leverage
+}
+
+// isLeveraged function returns true if the position is opened with leverage more than 1
+func isLeveraged(openedPosition *model.OpenedPosition) bool {
+	return positionLeverage(openedPosition.Leverage).GreaterThan(decimal.NewFromInt(1))
+}
+
+// validateLeverage function checks that leverage is within limits of margin rules
+// Initial margin of a position is its Total, so it is at least 1/MaxLeverage of the position value
+func validateLeverage(leverage decimal.Decimal, rules *model.MarginRules) error {
+	if leverage.LessThan(decimal.NewFromInt(1)) {
+		return model.NewValidationError("leverage", "leverage %v can't be less than 1", leverage)
+	}
+	if leverage.GreaterThan(rules.MaxLeverage) {
+		return model.NewValidationError("leverage", "leverage %v is more than maximal leverage %v", leverage, rules.MaxLeverage)
+	}
+	return nil
+}
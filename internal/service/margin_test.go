@@ -0,0 +1,107 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateMarginLevel(t *testing.T) {
+	rules := &model.MarginRules{MaxLeverage: dec("10"), MaintenanceMarginRate: dec("0.1"), MarginCallLevel: dec("100")}
+	leveraged := &model.OpenedPosition{
+		ShareName: "AAPL", IsLong: true, ShareOpenPrice: dec("10"), ShareAmount: dec("50"), Margin: dec("100"), Leverage: dec("5"),
+	}
+	unleveraged := &model.OpenedPosition{
+		ShareName: "TSLA", IsLong: false, ShareOpenPrice: dec("10"), ShareAmount: dec("10"), Margin: dec("100"), Leverage: dec("1"),
+	}
+	tests := []struct {
+		name              string
+		balance           string
+		openedPositions   []*model.OpenedPosition
+		prices            map[string]string
+		equity            string
+		maintenanceMargin string
+		level             string
+		marginCall        bool
+	}{
+		{
+			name:              "leveraged position above margin call level",
+			balance:           "1000",
+			openedPositions:   []*model.OpenedPosition{leveraged},
+			prices:            map[string]string{"AAPL": "9"},
+			equity:            "1050",
+			maintenanceMargin: "45",
+			level:             "2333.33",
+		},
+		{
+			name:              "leveraged position below margin call level",
+			balance:           "0",
+			openedPositions:   []*model.OpenedPosition{leveraged},
+			prices:            map[string]string{"AAPL": "8.2"},
+			equity:            "10",
+			maintenanceMargin: "41",
+			level:             "24.39",
+			marginCall:        true,
+		},
+		{
+			name:              "position without leverage requires no maintenance margin",
+			balance:           "1000",
+			openedPositions:   []*model.OpenedPosition{unleveraged},
+			prices:            map[string]string{"TSLA": "15"},
+			equity:            "1050",
+			maintenanceMargin: "0",
+			level:             "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := make(map[string]decimal.Decimal)
+			for shareName, price := range tt.prices {
+				prices[shareName] = dec(price)
+			}
+			marginLevel := calculateMarginLevel(uuid.New(), dec(tt.balance), tt.openedPositions, prices, rules, testRounding)
+			if !marginLevel.Equity.Equal(dec(tt.equity)) {
+				t.Errorf("equity is %v, want %v", marginLevel.Equity, tt.equity)
+			}
+			if !marginLevel.MaintenanceMargin.Equal(dec(tt.maintenanceMargin)) {
+				t.Errorf("maintenance margin is %v, want %v", marginLevel.MaintenanceMargin, tt.maintenanceMargin)
+			}
+			if !marginLevel.Level.Equal(dec(tt.level)) {
+				t.Errorf("margin level is %v%%, want %v%%", marginLevel.Level, tt.level)
+			}
+			if marginLevel.MarginCall != tt.marginCall {
+				t.Errorf("margin call is %v, want %v", marginLevel.MarginCall, tt.marginCall)
+			}
+		})
+	}
+}
+
+func TestValidateLeverage(t *testing.T) {
+	rules := &model.MarginRules{MaxLeverage: dec("10")}
+	tests := []struct {
+		leverage string
+		valid    bool
+	}{
+		{leverage: "0.5"},
+		{leverage: "1", valid: true},
+		{leverage: "2.5", valid: true},
+		{leverage: "10", valid: true},
+		{leverage: "10.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.leverage, func(t *testing.T) {
+			err := validateLeverage(dec(tt.leverage), rules)
+			if tt.valid && err != nil {
+				t.Errorf("validateLeverage: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, model.ErrInvalidArgument) {
+				t.Errorf("validateLeverage returned %v, want invalid argument", err)
+			}
+		})
+	}
+}
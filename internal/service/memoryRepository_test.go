@@ -0,0 +1,553 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// memoryRepository struct is an in-memory trading repository of service tests
+// Positions are checked by status and version the same way as in postgres, failures are injected by method name
+type memoryRepository struct {
+	TradingRepository
+	mu        sync.Mutex
+	positions map[uuid.UUID]*model.Position
+	sagas     map[uuid.UUID]*model.Saga
+	orders    map[uuid.UUID]*model.Order
+	keys      map[idempotencyKey]*model.IdempotencyRecord
+	events    []*model.OutboxEvent
+	failures  map[string]error
+}
+
+// idempotencyKey struct identifies an idempotency record like the primary key of its table
+type idempotencyKey struct {
+	profileID uuid.UUID
+	key       string
+	operation model.IdempotencyOperation
+}
+
+// newMemoryRepository creates a new empty memoryRepository
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		positions: make(map[uuid.UUID]*model.Position),
+		sagas:     make(map[uuid.UUID]*model.Saga),
+		orders:    make(map[uuid.UUID]*model.Order),
+		keys:      make(map[idempotencyKey]*model.IdempotencyRecord),
+		failures:  make(map[string]error),
+	}
+}
+
+// fail method makes every following call of the method return given error, nil error removes the failure
+// Method name may be followed by ID of an order to fail calls only for the order
+func (r *memoryRepository) fail(method string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		delete(r.failures, method)
+		return
+	}
+	r.failures[method] = err
+}
+
+// lockPosition method checks status and version of stored position like the repository does in a transaction
+func (r *memoryRepository) lockPosition(positionID uuid.UUID, status model.PositionStatus, version int64) (*model.Position, error) {
+	position, ok := r.positions[positionID]
+	if !ok {
+		return nil, fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotFound)
+	}
+	if position.Status == model.PositionStatusClosed {
+		return nil, fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotOpen)
+	}
+	if position.Status != status || position.Version != version {
+		return nil, fmt.Errorf("position %v: %w", positionID, model.ErrPositionConflict)
+	}
+	return position, nil
+}
+
+// setSagaStep method stores a step of the saga which is persisted together with the position
+func (r *memoryRepository) setSagaStep(saga *model.Saga) {
+	if stored, ok := r.sagas[saga.ID]; ok {
+		stored.Step = saga.Step
+		stored.UpdatedAt = saga.UpdatedAt
+	}
+}
+
+// CreatePosition method stores a copy of the opened position of the saga together with its step
+func (r *memoryRepository) CreatePosition(_ context.Context, saga *model.Saga, events ...*model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["CreatePosition"]; err != nil {
+		return err
+	}
+	created := *saga.Position
+	created.Status = model.PositionStatusOpen
+	r.positions[created.ID] = &created
+	r.setSagaStep(saga)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// StartClosingPosition method moves an opened position of the saga to closing status
+func (r *memoryRepository) StartClosingPosition(_ context.Context, saga *model.Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["StartClosingPosition"]; err != nil {
+		return err
+	}
+	position, err := r.lockPosition(saga.Position.ID, model.PositionStatusOpen, saga.Position.Version)
+	if err != nil {
+		return err
+	}
+	position.Status = model.PositionStatusClosing
+	position.Version++
+	r.setSagaStep(saga)
+	return nil
+}
+
+// ReopenPosition method moves a closing position back to open status
+func (r *memoryRepository) ReopenPosition(_ context.Context, positionID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["ReopenPosition"]; err != nil {
+		return err
+	}
+	if position, ok := r.positions[positionID]; ok && position.Status == model.PositionStatusClosing {
+		position.Status = model.PositionStatusOpen
+		position.Version++
+	}
+	return nil
+}
+
+// ClosePosition method moves a closing position of the saga to history together with step of the saga
+func (r *memoryRepository) ClosePosition(_ context.Context, saga *model.Saga, events ...*model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["ClosePosition"]; err != nil {
+		return err
+	}
+	position := saga.Position
+	stored, err := r.lockPosition(position.ID, model.PositionStatusClosing, position.Version)
+	if err != nil {
+		return err
+	}
+	closed := *position
+	closed.Status = model.PositionStatusClosed
+	closed.Version = stored.Version + 1
+	r.positions[position.ID] = &closed
+	r.setSagaStep(saga)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// ReducePosition method stores the remaining part of the position and closed part of the saga together with its step
+func (r *memoryRepository) ReducePosition(_ context.Context, saga *model.Saga, remaining *model.Position, events ...*model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["ReducePosition"]; err != nil {
+		return err
+	}
+	stored, err := r.lockPosition(remaining.ID, model.PositionStatusOpen, remaining.Version)
+	if err != nil {
+		return err
+	}
+	stored.Total = remaining.Total
+	stored.ShareAmount = remaining.ShareAmount
+	stored.OpenFee = remaining.OpenFee
+	stored.Version++
+	closed := *saga.Position
+	closed.Status = model.PositionStatusClosed
+	r.positions[closed.ID] = &closed
+	r.setSagaStep(saga)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// IncreasePosition method stores increased position of the saga together with its step
+func (r *memoryRepository) IncreasePosition(_ context.Context, saga *model.Saga, previous *model.Position, events ...*model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["IncreasePosition"]; err != nil {
+		return err
+	}
+	stored, err := r.lockPosition(previous.ID, model.PositionStatusOpen, previous.Version)
+	if err != nil {
+		return err
+	}
+	stored.SharePrice = saga.Position.SharePrice
+	stored.Total = saga.Position.Total
+	stored.ShareAmount = saga.Position.ShareAmount
+	stored.OpenFee = saga.Position.OpenFee
+	stored.Version++
+	r.setSagaStep(saga)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// GetPositionByID method returns a copy of stored position
+func (r *memoryRepository) GetPositionByID(_ context.Context, positionID uuid.UUID) (*model.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	position, ok := r.positions[positionID]
+	if !ok {
+		return nil, fmt.Errorf("position %v: %w", positionID, model.ErrPositionNotFound)
+	}
+	copied := *position
+	return &copied, nil
+}
+
+// CreateSaga method stores a copy of the saga
+func (r *memoryRepository) CreateSaga(_ context.Context, saga *model.Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["CreateSaga"]; err != nil {
+		return err
+	}
+	copied := *saga
+	r.sagas[saga.ID] = &copied
+	return nil
+}
+
+// UpdateSaga method stores step and status of the saga
+func (r *memoryRepository) UpdateSaga(_ context.Context, saga *model.Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["UpdateSaga"]; err != nil {
+		return err
+	}
+	copied := *saga
+	r.sagas[saga.ID] = &copied
+	return nil
+}
+
+// GetPendingSagas method returns copies of pending sagas updated before given time
+func (r *memoryRepository) GetPendingSagas(_ context.Context, before time.Time) ([]*model.Saga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sagas []*model.Saga
+	for _, saga := range r.sagas {
+		if saga.Status == model.SagaStatusPending && saga.UpdatedAt.Before(before) {
+			copied := *saga
+			position := *saga.Position
+			copied.Position = &position
+			sagas = append(sagas, &copied)
+		}
+	}
+	return sagas, nil
+}
+
+// saga method returns a copy of stored saga of given ID
+func (r *memoryRepository) saga(sagaID uuid.UUID) *model.Saga {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *r.sagas[sagaID]
+	return &copied
+}
+
+// CreateOrders method stores copies of the orders
+func (r *memoryRepository) CreateOrders(_ context.Context, orders ...*model.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, order := range orders {
+		copied := *order
+		r.orders[order.ID] = &copied
+	}
+	return nil
+}
+
+// GetExitOrders method returns copies of exit orders of the entry order
+func (r *memoryRepository) GetExitOrders(_ context.Context, entryID uuid.UUID) ([]*model.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var exits []*model.Order
+	for _, order := range r.orders {
+		if order.ParentID != nil && *order.ParentID == entryID {
+			copied := *order
+			exits = append(exits, &copied)
+		}
+	}
+	return exits, nil
+}
+
+// GetOrderByID method returns a copy of stored order
+func (r *memoryRepository) GetOrderByID(_ context.Context, orderID uuid.UUID) (*model.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %v: %w", orderID, model.ErrOrderNotFound)
+	}
+	copied := *order
+	return &copied, nil
+}
+
+// UpdateOrderStatus method stores status of the order if it is still in expected status
+func (r *memoryRepository) UpdateOrderStatus(_ context.Context, order *model.Order, expected model.OrderStatus, events ...*model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.failures["UpdateOrderStatus"]; err != nil {
+		return err
+	}
+	if err := r.failures["UpdateOrderStatus "+order.ID.String()]; err != nil {
+		return err
+	}
+	stored, ok := r.orders[order.ID]
+	if !ok || stored.Status != expected {
+		return fmt.Errorf("order %v: %w", order.ID, model.ErrOrderNotFound)
+	}
+	copied := *order
+	r.orders[order.ID] = &copied
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// order method returns a copy of stored order of given ID
+func (r *memoryRepository) order(orderID uuid.UUID) *model.Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *r.orders[orderID]
+	return &copied
+}
+
+// ClaimIdempotencyKey method stores a copy of pending record if the key is free or expired
+func (r *memoryRepository) ClaimIdempotencyKey(_ context.Context, record *model.IdempotencyRecord) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := idempotencyKey{profileID: record.ProfileID, key: record.Key, operation: record.Operation}
+	if stored, ok := r.keys[id]; ok && stored.ExpiresAt.After(record.CreatedAt) {
+		return false, nil
+	}
+	copied := *record
+	r.keys[id] = &copied
+	return true, nil
+}
+
+// GetIdempotencyRecord method returns a copy of stored record
+func (r *memoryRepository) GetIdempotencyRecord(_ context.Context, profileID uuid.UUID, key string, operation model.IdempotencyOperation) (*model.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.keys[idempotencyKey{profileID: profileID, key: key, operation: operation}]
+	if !ok {
+		return nil, model.ErrIdempotencyKeyNotFound
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+// ReclaimIdempotencyKey method extends the lease of pending record of the same request whose lease is over
+func (r *memoryRepository) ReclaimIdempotencyKey(_ context.Context, record *model.IdempotencyRecord) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.keys[idempotencyKey{profileID: record.ProfileID, key: record.Key, operation: record.Operation}]
+	if !ok || stored.RequestHash != record.RequestHash || stored.Status != model.IdempotencyStatusPending ||
+		stored.LockedUntil.After(record.CreatedAt) || !stored.ExpiresAt.After(record.CreatedAt) {
+		return false, nil
+	}
+	stored.LockedUntil = record.LockedUntil
+	return true, nil
+}
+
+// CompleteIdempotencyKey method stores status and response of the record
+func (r *memoryRepository) CompleteIdempotencyKey(_ context.Context, record *model.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.keys[idempotencyKey{profileID: record.ProfileID, key: record.Key, operation: record.Operation}]; ok {
+		stored.Status = record.Status
+		stored.Response = record.Response
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey method frees the key
+func (r *memoryRepository) DeleteIdempotencyKey(_ context.Context, profileID uuid.UUID, key string, operation model.IdempotencyOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, idempotencyKey{profileID: profileID, key: key, operation: operation})
+	return nil
+}
+
+// GetMonthlyVolume method returns no trading volume
+func (r *memoryRepository) GetMonthlyVolume(context.Context, uuid.UUID, time.Time) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+// GetRiskLimits method reports that profiles have no own risk limits
+func (r *memoryRepository) GetRiskLimits(context.Context, uuid.UUID) (*model.RiskLimits, error) {
+	return nil, model.ErrRiskLimitsNotFound
+}
+
+// GetRealizedPnL method returns no realized PnL
+func (r *memoryRepository) GetRealizedPnL(context.Context, uuid.UUID, time.Time) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+// memoryBalance struct is an in-memory balance repository of service tests, operations are idempotent by key
+type memoryBalance struct {
+	mu         sync.Mutex
+	balances   map[uuid.UUID]decimal.Decimal
+	operations map[uuid.UUID]*model.BalanceOperation
+	failures   map[string]error
+}
+
+// newMemoryBalance creates a new memoryBalance with given balance of the profile
+func newMemoryBalance(profileID uuid.UUID, balance decimal.Decimal) *memoryBalance {
+	return &memoryBalance{
+		balances:   map[uuid.UUID]decimal.Decimal{profileID: balance},
+		operations: make(map[uuid.UUID]*model.BalanceOperation),
+		failures:   make(map[string]error),
+	}
+}
+
+// fail method makes every following call of the method return given error, nil error removes the failure
+func (b *memoryBalance) fail(method string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.failures, method)
+		return
+	}
+	b.failures[method] = err
+}
+
+// GetBalance method returns balance of the profile
+func (b *memoryBalance) GetBalance(_ context.Context, profileID uuid.UUID) (*model.Balance, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &model.Balance{ProfileID: profileID, Balance: b.balances[profileID]}, nil
+}
+
+// Reserve method holds money on the balance
+func (b *memoryBalance) Reserve(_ context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.failures["Reserve"]; err != nil {
+		return err
+	}
+	if _, ok := b.operations[key]; ok {
+		return nil
+	}
+	if b.balances[profileID].Sub(b.reserved(profileID)).LessThan(amount) {
+		return model.ErrInsufficientFunds
+	}
+	b.operations[key] = &model.BalanceOperation{Key: key, ProfileID: profileID, Amount: amount.Neg(), Status: model.BalanceOperationReserved}
+	return nil
+}
+
+// reserved method returns money held by reservations of the profile
+func (b *memoryBalance) reserved(profileID uuid.UUID) decimal.Decimal {
+	reserved := decimal.Zero
+	for _, operation := range b.operations {
+		if operation.ProfileID == profileID && operation.Status == model.BalanceOperationReserved {
+			reserved = reserved.Sub(operation.Amount)
+		}
+	}
+	return reserved
+}
+
+// Commit method debits reserved money from the balance
+func (b *memoryBalance) Commit(_ context.Context, key uuid.UUID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.failures["Commit"]; err != nil {
+		return err
+	}
+	operation, ok := b.operations[key]
+	if !ok || operation.Status == model.BalanceOperationReleased {
+		return fmt.Errorf("reservation %v not found", key)
+	}
+	if operation.Status == model.BalanceOperationReserved {
+		operation.Status = model.BalanceOperationCommitted
+		b.balances[operation.ProfileID] = b.balances[operation.ProfileID].Add(operation.Amount)
+	}
+	return nil
+}
+
+// Release method cancels the reservation
+func (b *memoryBalance) Release(_ context.Context, key uuid.UUID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.failures["Release"]; err != nil {
+		return err
+	}
+	operation, ok := b.operations[key]
+	if !ok {
+		return nil
+	}
+	if operation.Status == model.BalanceOperationCommitted {
+		return fmt.Errorf("reservation %v is already committed", key)
+	}
+	operation.Status = model.BalanceOperationReleased
+	return nil
+}
+
+// Credit method adds money to the balance
+func (b *memoryBalance) Credit(_ context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.failures["Credit"]; err != nil {
+		return err
+	}
+	if _, ok := b.operations[key]; ok {
+		return nil
+	}
+	b.operations[key] = &model.BalanceOperation{Key: key, ProfileID: profileID, Amount: amount, Status: model.BalanceOperationCommitted}
+	b.balances[profileID] = b.balances[profileID].Add(amount)
+	return nil
+}
+
+// balance method returns balance of the profile
+func (b *memoryBalance) balance(profileID uuid.UUID) decimal.Decimal {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.balances[profileID]
+}
+
+// fixedPriceService struct is a price service with fixed prices of shares
+type fixedPriceService struct {
+	PriceServiceRepository
+	mu     sync.Mutex
+	prices map[string]decimal.Decimal
+}
+
+// AddSubscriber method returns fixed price of the share
+func (p *fixedPriceService) AddSubscriber(_ context.Context, selectedShares []string) (*model.Share, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	price, ok := p.prices[selectedShares[0]]
+	if !ok {
+		return nil, fmt.Errorf("no price of %s", selectedShares[0])
+	}
+	return &model.Share{ShareName: selectedShares[0], SharePrice: price}, nil
+}
+
+// setPrice method changes fixed price of the share
+func (p *fixedPriceService) setPrice(shareName string, price decimal.Decimal) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prices[shareName] = price
+}
+
+// AddShares method does nothing as prices are fixed
+func (p *fixedPriceService) AddShares(...string) {}
+
+// RemoveShares method does nothing as prices are fixed
+func (p *fixedPriceService) RemoveShares(...string) {}
+
+// newMemoryService creates a trading service over in-memory repositories without commissions and risk limits
+// Instrument of the share is registered with given precision of amounts of shares
+func newMemoryService(rps *memoryRepository, balance *memoryBalance, shareName string, price decimal.Decimal, amountScale int32) (*TradingService, *fixedPriceService) {
+	prices := &fixedPriceService{prices: map[string]decimal.Decimal{shareName: price}}
+	instruments := model.NewInstrumentRegistry()
+	instruments.Instruments[shareName] = &model.Instrument{ShareName: shareName, TickSize: dec("0.01"), QuantityScale: amountScale, Tradable: true}
+	s := NewTradingService(rps, prices, balance, model.NewPositionManager(),
+		&model.MarginRules{MaxLeverage: dec("10")},
+		&model.TradingSchedule{MarketClose: 16 * time.Hour, Location: time.UTC},
+		testRounding, instruments, &model.CommissionRules{}, &model.RiskLimits{}, time.Hour)
+	return s, prices
+}
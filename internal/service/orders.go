@@ -0,0 +1,609 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// PlaceOrder method validates and stores a pending entry order and starts matching it against share prices
+// For a bracket, stop loss and take profit of the entry order become its exit orders which are returned
+// IOC and FOK orders are matched once against current share price and expire if they are not triggered
+func (s *TradingService) PlaceOrder(ctx context.Context, order *model.Order, bracket bool) ([]*model.Order, error) {
+	instrument, err := s.GetTradableInstrument(order.ShareName)
+	if err != nil {
+		return nil, fmt.Errorf("GetTradableInstrument: %w", err)
+	}
+	precision := instrument.Precision()
+	order.TriggerPrice = precision.Price(order.TriggerPrice)
+	order.StopLoss = precision.Price(order.StopLoss)
+	order.TakeProfit = precision.Price(order.TakeProfit)
+	order.Total = s.rounding.Money(order.Total)
+	err = validateOrder(order)
+	if err != nil {
+		return nil, fmt.Errorf("validateOrder: %w", err)
+	}
+	err = checkOrderValue(instrument, order.Total)
+	if err != nil {
+		return nil, fmt.Errorf("checkOrderValue: %w", err)
+	}
+	now := time.Now()
+	err = s.applyTimeInForce(order, order.TimeInForce, order.ExpiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("applyTimeInForce: %w", err)
+	}
+	order.Status = model.OrderStatusPending
+	order.PositionID = uuid.New()
+	order.ParentID = nil
+	order.Error = ""
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	var exits []*model.Order
+	if bracket {
+		exits = newExitOrders(order)
+		if len(exits) == 0 {
+			return nil, model.NewValidationError("bracket", "bracket order must have stop loss or take profit")
+		}
+		// levels are watched by exit orders instead of the position
+		order.StopLoss = decimal.Zero
+		order.TakeProfit = decimal.Zero
+	}
+	err = s.rps.CreateOrders(ctx, append([]*model.Order{order}, exits...)...)
+	if err != nil {
+		return nil, fmt.Errorf("CreateOrders: %w", err)
+	}
+	if order.IsImmediate() {
+		err = s.fillImmediately(ctx, order)
+		if err != nil {
+			return nil, fmt.Errorf("fillImmediately: %w", err)
+		}
+		return exits, nil
+	}
+	s.addOrderToBook(order)
+	return exits, nil
+}
+
+// fillImmediately method fills IOC or FOK order if current share price triggers it, otherwise the order expires
+func (s *TradingService) fillImmediately(ctx context.Context, order *model.Order) error {
+	sharePrice, err := s.getSharePrice(ctx, order.ShareName)
+	if err != nil {
+		return fmt.Errorf("getSharePrice: %w", err)
+	}
+	if checkOrderTrigger(order, sharePrice) {
+		err = s.fillOrder(ctx, order)
+		if err != nil && order.Status == model.OrderStatusPending {
+			// order can't wait for the next match, it expires with the error
+			order.Error = err.Error()
+			expireErr := s.expireOrder(ctx, order)
+			if expireErr != nil {
+				logrus.WithFields(logrus.Fields{"OrderID": order.ID}).Errorf("expireOrder: %v", expireErr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("fillOrder: %w", err)
+		}
+		return nil
+	}
+	order.Error = fmt.Sprintf("share price %v doesn't trigger the order", sharePrice)
+	err = s.expireOrder(ctx, order)
+	if err != nil {
+		return fmt.Errorf("expireOrder: %w", err)
+	}
+	return nil
+}
+
+// CancelOrder method cancels a pending order of given ID, inactive exit orders of canceled entry order are canceled too
+func (s *TradingService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.rps.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("GetOrderByID: %w", err)
+	}
+	switch order.Status {
+	case model.OrderStatusInactive:
+		order.Status = model.OrderStatusCanceled
+		order.UpdatedAt = time.Now()
+		err = s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusInactive)
+		if err != nil {
+			return fmt.Errorf("UpdateOrderStatus: %w", err)
+		}
+		return nil
+	case model.OrderStatusPending:
+	default:
+		return fmt.Errorf("order %v is already %v: %w", order.ID, order.Status, model.ErrOrderNotActive)
+	}
+	// order is taken from the book first, so matcher can't fill it while it is being canceled
+	if !s.takeOrderFromBook(order.ID) {
+		return fmt.Errorf("order %v is being filled: %w", order.ID, model.ErrOrderConflict)
+	}
+	order.Status = model.OrderStatusCanceled
+	order.UpdatedAt = time.Now()
+	err = s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusPending)
+	if err != nil {
+		order.Status = model.OrderStatusPending
+		s.addOrderToBook(order)
+		return fmt.Errorf("UpdateOrderStatus: %w", err)
+	}
+	if !order.IsExit() {
+		s.finishExitOrders(ctx, order)
+	}
+	return nil
+}
+
+// AmendOrder method changes trigger price and time-in-force of the order which is not triggered yet
+func (s *TradingService) AmendOrder(ctx context.Context, orderID uuid.UUID, amendment *model.OrderAmendment) (*model.Order, error) {
+	if amendment.TriggerPrice.IsNegative() {
+		return nil, model.NewValidationError("triggerPrice", "trigger price must be positive")
+	}
+	order, err := s.rps.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("GetOrderByID: %w", err)
+	}
+	if order.Status != model.OrderStatusPending && order.Status != model.OrderStatusInactive {
+		return nil, fmt.Errorf("order %v is already %v: %w", order.ID, order.Status, model.ErrOrderNotActive)
+	}
+	now := time.Now()
+	if amendment.TriggerPrice.IsPositive() {
+		order.TriggerPrice = s.precision(order.ShareName).Price(amendment.TriggerPrice)
+	}
+	if amendment.TimeInForce != "" || amendment.ExpiresAt != nil {
+		timeInForce := amendment.TimeInForce
+		if timeInForce == model.TimeInForceIOC || timeInForce == model.TimeInForceFOK {
+			return nil, model.NewValidationError("timeInForce", "time in force of placed order can't be changed to %v", timeInForce)
+		}
+		err = s.applyTimeInForce(order, timeInForce, amendment.ExpiresAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("applyTimeInForce: %w", err)
+		}
+	}
+	if !order.IsExit() {
+		err = validateOrder(order)
+		if err != nil {
+			return nil, fmt.Errorf("validateOrder: %w", err)
+		}
+	}
+	order.UpdatedAt = now
+	err = s.rps.AmendOrder(ctx, order)
+	if err != nil {
+		return nil, fmt.Errorf("AmendOrder: %w", err)
+	}
+	s.amendOrderInBook(order)
+	return order, nil
+}
+
+// applyTimeInForce method sets time-in-force of the order and calculates its expiry time
+// DAY order expires at the next market close, GTD order needs an expiry time in the future
+// Unspecified time-in-force is GTD if expiry time is given and GTC otherwise
+func (s *TradingService) applyTimeInForce(order *model.Order, timeInForce model.TimeInForce, expiresAt *time.Time, now time.Time) error {
+	if timeInForce == "" {
+		timeInForce = model.TimeInForceGTC
+		if expiresAt != nil {
+			timeInForce = model.TimeInForceGTD
+		}
+	}
+	switch timeInForce {
+	case model.TimeInForceGTC, model.TimeInForceIOC, model.TimeInForceFOK:
+		expiresAt = nil
+	case model.TimeInForceGTD:
+		if expiresAt == nil || !expiresAt.After(now) {
+			return model.NewValidationError("expiresAt", "GTD order must have expiry time in the future")
+		}
+	case model.TimeInForceDAY:
+		marketClose := s.schedule.NextMarketClose(now)
+		expiresAt = &marketClose
+	default:
+		return model.NewValidationError("timeInForce", "unknown time in force %q", timeInForce)
+	}
+	order.TimeInForce = timeInForce
+	order.ExpiresAt = expiresAt
+	return nil
+}
+
+// ListOrders method returns a page of orders matching given filter and a cursor of the next page
+func (s *TradingService) ListOrders(ctx context.Context, filter *model.OrderFilter) ([]*model.Order, uuid.UUID, error) {
+	if filter.Limit <= 0 || filter.Limit > maxPositionsLimit {
+		filter.Limit = defaultPositionsLimit
+	}
+	limit := filter.Limit
+	// requesting one extra order to find out if there is a next page
+	filter.Limit++
+	orders, err := s.rps.ListOrders(ctx, filter)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("ListOrders: %w", err)
+	}
+	nextCursor := uuid.Nil
+	if len(orders) > limit {
+		orders = orders[:limit]
+		nextCursor = orders[limit-1].ID
+	}
+	return orders, nextCursor, nil
+}
+
+// RestoreOrders method loads pending orders from database into the order book
+// Inactive exit orders whose entry order was finished before a crash are activated or canceled
+func (s *TradingService) RestoreOrders(ctx context.Context) (int, error) {
+	orders, err := s.rps.GetPendingOrders(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("GetPendingOrders: %w", err)
+	}
+	restored := 0
+	finishedEntries := make(map[uuid.UUID]bool)
+	for _, order := range orders {
+		if order.Status == model.OrderStatusPending && order.IsImmediate() {
+			// immediate order wasn't matched before a crash, so it is too late to fill it
+			order.Error = "order wasn't matched immediately"
+			err = s.expireOrder(ctx, order)
+			if err != nil {
+				return restored, fmt.Errorf("expireOrder: %w", err)
+			}
+			continue
+		}
+		if order.Status == model.OrderStatusPending {
+			s.addOrderToBook(order)
+			restored++
+			continue
+		}
+		if finishedEntries[*order.ParentID] {
+			continue
+		}
+		entry, err := s.rps.GetOrderByID(ctx, *order.ParentID)
+		if err != nil {
+			return restored, fmt.Errorf("GetOrderByID: %w", err)
+		}
+		if entry.Status != model.OrderStatusPending {
+			finishedEntries[entry.ID] = true
+			s.finishExitOrders(ctx, entry)
+		}
+	}
+	return restored, nil
+}
+
+// MatchOrders method matches pending orders against share prices with given interval until context is done
+func (s *TradingService) MatchOrders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.matchOrders(ctx)
+		}
+	}
+}
+
+// matchOrders method fetches current prices of shares of pending orders and fills triggered orders
+func (s *TradingService) matchOrders(ctx context.Context) {
+	prices := make(map[string]decimal.Decimal)
+	for _, order := range s.getPendingOrders() {
+		price, ok := prices[order.ShareName]
+		if !ok {
+			var err error
+			price, err = s.getSharePrice(ctx, order.ShareName)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"ShareName": order.ShareName}).Errorf("getSharePrice: %v", err)
+				continue
+			}
+			prices[order.ShareName] = price
+		}
+		if !checkOrderTrigger(order, price) {
+			continue
+		}
+		if !s.takeOrderFromBook(order.ID) {
+			continue
+		}
+		var err error
+		if order.IsExit() {
+			err = s.fillExitOrder(ctx, order, price)
+		} else {
+			err = s.fillOrder(ctx, order)
+		}
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"OrderID": order.ID, "Type": order.Type}).Errorf("fillOrder: %v", err)
+		}
+	}
+}
+
+// fillOrder method opens a position of the triggered order and records the result in the order
+// Position ID is assigned when order is placed, so an order whose position was opened before a crash is not filled twice
+// Order is rejected only if its position can't be opened by business rules, on temporary failures it stays pending
+// and is matched again, immediate order is left out of the book to be expired by the caller
+func (s *TradingService) fillOrder(ctx context.Context, order *model.Order) error {
+	_, err := s.rps.GetPositionByID(ctx, order.PositionID)
+	switch {
+	case err == nil:
+		order.Status = model.OrderStatusFilled
+	case errors.Is(err, model.ErrPositionNotFound):
+		position := &model.Position{
+			ID:         order.PositionID,
+			ProfileID:  order.ProfileID,
+			IsLong:     order.IsLong(),
+			ShareName:  order.ShareName,
+			Total:      order.Total,
+			StopLoss:   order.StopLoss,
+			TakeProfit: order.TakeProfit,
+		}
+		err = s.openPosition(ctx, position)
+		if err != nil && !isOrderRejection(err) {
+			if !order.IsImmediate() {
+				s.addOrderToBook(order)
+			}
+			return fmt.Errorf("openPosition: %w", err)
+		}
+		order.Status = model.OrderStatusFilled
+		if err != nil {
+			order.Status = model.OrderStatusRejected
+			order.Error = err.Error()
+		}
+	default:
+		if !order.IsImmediate() {
+			s.addOrderToBook(order)
+		}
+		return fmt.Errorf("GetPositionByID: %w", err)
+	}
+	order.UpdatedAt = time.Now()
+	err = s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusPending)
+	if err != nil {
+		return fmt.Errorf("UpdateOrderStatus: %w", err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"OrderID":    order.ID,
+		"PositionID": order.PositionID,
+		"Status":     order.Status,
+		"Error":      order.Error,
+	}).Info("order triggered")
+	s.finishExitOrders(ctx, order)
+	return nil
+}
+
+// fillExitOrder method closes the position of triggered exit order and cancels the other exit order of the bracket
+// Exit order of already closed position is canceled
+func (s *TradingService) fillExitOrder(ctx context.Context, order *model.Order, price decimal.Decimal) error {
+	reason := model.CloseReasonStopLoss
+	if order.Type == model.OrderBuyLimit || order.Type == model.OrderSellLimit {
+		reason = model.CloseReasonTakeProfit
+	}
+	position, err := s.rps.GetPositionByID(ctx, order.PositionID)
+	if err != nil {
+		s.addOrderToBook(order)
+		return fmt.Errorf("GetPositionByID: %w", err)
+	}
+	if position.Status == model.PositionStatusClosing {
+		// position is being closed by another request, order is matched again on next tick in case the closing fails
+		s.addOrderToBook(order)
+		return nil
+	}
+	order.Status = model.OrderStatusFilled
+	if position.Status != model.PositionStatusOpen {
+		order.Status = model.OrderStatusCanceled
+		order.Error = fmt.Sprintf("position %v is already closed", position.ID)
+	} else {
+		if !s.markPositionClosing(position.ID) {
+			// position is being changed right now, order will be matched again on next tick
+			s.addOrderToBook(order)
+			return nil
+		}
+		_, err = s.closePosition(ctx, position, price, reason)
+		if err != nil {
+			s.unmarkPositionClosing(position.ID)
+			s.addOrderToBook(order)
+			return fmt.Errorf("closePosition: %w", err)
+		}
+	}
+	order.UpdatedAt = time.Now()
+	err = s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusPending)
+	if err != nil {
+		return fmt.Errorf("UpdateOrderStatus: %w", err)
+	}
+	s.cancelSiblingOrders(ctx, order)
+	logrus.WithFields(logrus.Fields{
+		"OrderID":    order.ID,
+		"PositionID": order.PositionID,
+		"Status":     order.Status,
+		"Reason":     reason,
+	}).Info("exit order triggered")
+	return nil
+}
+
+// finishExitOrders method activates inactive exit orders of filled entry order or cancels them if entry order was not filled
+func (s *TradingService) finishExitOrders(ctx context.Context, entry *model.Order) {
+	exits, err := s.rps.GetExitOrders(ctx, entry.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"OrderID": entry.ID}).Errorf("GetExitOrders: %v", err)
+		return
+	}
+	for _, exit := range exits {
+		if exit.Status != model.OrderStatusInactive {
+			continue
+		}
+		exit.Status = model.OrderStatusCanceled
+		if entry.Status == model.OrderStatusFilled {
+			exit.Status = model.OrderStatusPending
+		}
+		exit.UpdatedAt = time.Now()
+		err = s.rps.UpdateOrderStatus(ctx, exit, model.OrderStatusInactive)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"OrderID": exit.ID}).Errorf("UpdateOrderStatus: %v", err)
+			continue
+		}
+		if exit.Status == model.OrderStatusPending {
+			s.addOrderToBook(exit)
+		}
+	}
+}
+
+// cancelSiblingOrders method cancels other pending exit orders of the bracket of given exit order
+// Sibling which can't be canceled is returned to the book, it is canceled when it is triggered as its position is closed
+func (s *TradingService) cancelSiblingOrders(ctx context.Context, order *model.Order) {
+	siblings, err := s.rps.GetExitOrders(ctx, *order.ParentID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"OrderID": order.ID}).Errorf("GetExitOrders: %v", err)
+		return
+	}
+	for _, sibling := range siblings {
+		if sibling.ID == order.ID || sibling.Status != model.OrderStatusPending {
+			continue
+		}
+		s.takeOrderFromBook(sibling.ID)
+		sibling.Status = model.OrderStatusCanceled
+		sibling.UpdatedAt = time.Now()
+		err = s.rps.UpdateOrderStatus(ctx, sibling, model.OrderStatusPending)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"OrderID": sibling.ID}).Errorf("UpdateOrderStatus: %v", err)
+			sibling.Status = model.OrderStatusPending
+			s.addOrderToBook(sibling)
+		}
+	}
+}
+
+// cancelExitOrders method cancels pending exit orders of the closed position which are in the book
+// Exit order which closed the position is already taken from the book, so it is left to be filled
+// Order which can't be canceled is returned to the book, it is canceled when it is triggered as its position is closed
+func (s *TradingService) cancelExitOrders(ctx context.Context, positionID uuid.UUID) {
+	for _, order := range s.getPendingOrders() {
+		if !order.IsExit() || order.PositionID != positionID || !s.takeOrderFromBook(order.ID) {
+			continue
+		}
+		order.Status = model.OrderStatusCanceled
+		order.Error = fmt.Sprintf("position %v is closed", positionID)
+		order.UpdatedAt = time.Now()
+		err := s.rps.UpdateOrderStatus(ctx, order, model.OrderStatusPending)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"OrderID": order.ID}).Errorf("UpdateOrderStatus: %v", err)
+			order.Status = model.OrderStatusPending
+			order.Error = ""
+			s.addOrderToBook(order)
+		}
+	}
+}
+
+// addOrderToBook method adds a pending order to position manager and subscribes to its share
+func (s *TradingService) addOrderToBook(order *model.Order) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	if _, ok := s.positionManager.PendingOrders[order.ID]; ok {
+		return
+	}
+	s.positionManager.PendingOrders[order.ID] = order
+	s.priceServiceRps.AddShares(order.ShareName)
+}
+
+// takeOrderFromBook method removes a pending order from position manager, returns false if it is not there
+func (s *TradingService) takeOrderFromBook(orderID uuid.UUID) bool {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	order, ok := s.positionManager.PendingOrders[orderID]
+	if !ok {
+		return false
+	}
+	delete(s.positionManager.PendingOrders, orderID)
+	s.priceServiceRps.RemoveShares(order.ShareName)
+	return true
+}
+
+// amendOrderInBook method sets trigger price and expiry of a pending order in position manager
+func (s *TradingService) amendOrderInBook(amended *model.Order) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	if order, ok := s.positionManager.PendingOrders[amended.ID]; ok {
+		order.TriggerPrice = amended.TriggerPrice
+		order.TimeInForce = amended.TimeInForce
+		order.ExpiresAt = amended.ExpiresAt
+	}
+}
+
+// getPendingOrders method returns a snapshot of pending orders from position manager
+func (s *TradingService) getPendingOrders() []*model.Order {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	orders := make([]*model.Order, 0, len(s.positionManager.PendingOrders))
+	for _, order := range s.positionManager.PendingOrders {
+		copied := *order
+		orders = append(orders, &copied)
+	}
+	return orders
+}
+
+// newExitOrders function creates inactive take profit and stop loss exit orders of the bracket entry order
+// Exit of long position sells shares, so its take profit is a sell limit and its stop loss is a sell stop
+func newExitOrders(entry *model.Order) []*model.Order {
+	takeProfitType, stopLossType := model.OrderSellLimit, model.OrderSellStop
+	if !entry.IsLong() {
+		takeProfitType, stopLossType = model.OrderBuyLimit, model.OrderBuyStop
+	}
+	newExit := func(orderType model.OrderType, triggerPrice decimal.Decimal) *model.Order {
+		return &model.Order{
+			ID:           uuid.New(),
+			ProfileID:    entry.ProfileID,
+			ShareName:    entry.ShareName,
+			Type:         orderType,
+			TriggerPrice: triggerPrice,
+			Status:       model.OrderStatusInactive,
+			TimeInForce:  model.TimeInForceGTC,
+			PositionID:   entry.PositionID,
+			ParentID:     &entry.ID,
+			CreatedAt:    entry.CreatedAt,
+			UpdatedAt:    entry.UpdatedAt,
+		}
+	}
+	var exits []*model.Order
+	if entry.TakeProfit.IsPositive() {
+		exits = append(exits, newExit(takeProfitType, entry.TakeProfit))
+	}
+	if entry.StopLoss.IsPositive() {
+		exits = append(exits, newExit(stopLossType, entry.StopLoss))
+	}
+	return exits
+}
+
+// isOrderRejection function checks if the order must be rejected because of the error of opening its position
+// Invalid orders, rejections by risk limits, insufficient funds and untradable instruments won't succeed on retry
+func isOrderRejection(err error) bool {
+	var riskErr *model.RiskLimitError
+	return errors.Is(err, model.ErrInvalidArgument) ||
+		errors.As(err, &riskErr) ||
+		errors.Is(err, model.ErrInsufficientFunds) ||
+		errors.Is(err, model.ErrInstrumentNotFound) ||
+		errors.Is(err, model.ErrInstrumentNotTradable)
+}
+
+// checkOrderTrigger function checks if share price reached trigger price of the order
+// Limit orders enter at a better price than trigger, stop orders enter on a breakout through trigger
+func checkOrderTrigger(order *model.Order, currentSharePrice decimal.Decimal) bool {
+	switch order.Type {
+	case model.OrderBuyLimit, model.OrderSellStop:
+		return currentSharePrice.LessThanOrEqual(order.TriggerPrice)
+	case model.OrderSellLimit, model.OrderBuyStop:
+		return currentSharePrice.GreaterThanOrEqual(order.TriggerPrice)
+	}
+	return false
+}
+
+// validateOrder function checks type, prices and amount of the order
+// Stop loss and take profit are checked against trigger price as the expected open price
+func validateOrder(order *model.Order) error {
+	switch order.Type {
+	case model.OrderBuyLimit, model.OrderSellLimit, model.OrderBuyStop, model.OrderSellStop:
+	default:
+		return model.NewValidationError("type", "unknown order type %q", order.Type)
+	}
+	if !order.TriggerPrice.IsPositive() {
+		return model.NewValidationError("triggerPrice", "trigger price must be positive")
+	}
+	if !order.Total.IsPositive() {
+		return model.NewValidationError("total", "total must be positive")
+	}
+	return validateStopLossAndTakeProfit(&model.Position{
+		IsLong:     order.IsLong(),
+		SharePrice: order.TriggerPrice,
+		StopLoss:   order.StopLoss,
+		TakeProfit: order.TakeProfit,
+	})
+}
@@ -0,0 +1,263 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckOrderTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderType model.OrderType
+		price     string
+		triggered bool
+	}{
+		{name: "buy limit above trigger", orderType: model.OrderBuyLimit, price: "101"},
+		{name: "buy limit at trigger", orderType: model.OrderBuyLimit, price: "100", triggered: true},
+		{name: "buy limit below trigger", orderType: model.OrderBuyLimit, price: "99", triggered: true},
+		{name: "sell limit below trigger", orderType: model.OrderSellLimit, price: "99"},
+		{name: "sell limit at trigger", orderType: model.OrderSellLimit, price: "100", triggered: true},
+		{name: "buy stop below trigger", orderType: model.OrderBuyStop, price: "99"},
+		{name: "buy stop above trigger", orderType: model.OrderBuyStop, price: "101", triggered: true},
+		{name: "sell stop above trigger", orderType: model.OrderSellStop, price: "101"},
+		{name: "sell stop below trigger", orderType: model.OrderSellStop, price: "99", triggered: true},
+		{name: "unknown type", orderType: model.OrderType("unknown"), price: "100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &model.Order{Type: tt.orderType, TriggerPrice: dec("100")}
+			if triggered := checkOrderTrigger(order, dec(tt.price)); triggered != tt.triggered {
+				t.Errorf("checkOrderTrigger at %v = %v, want %v", tt.price, triggered, tt.triggered)
+			}
+		})
+	}
+}
+
+func TestIsOrderRejection(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		rejected bool
+	}{
+		{name: "validation error", err: fmt.Errorf("openPosition: %w", model.NewValidationError("total", "total must be positive")), rejected: true},
+		{name: "risk limits", err: fmt.Errorf("checkRiskLimits: %w", &model.RiskLimitError{}), rejected: true},
+		{name: "insufficient funds", err: fmt.Errorf("ReserveBalance: %w", model.ErrInsufficientFunds), rejected: true},
+		{name: "unknown instrument", err: model.ErrInstrumentNotFound, rejected: true},
+		{name: "instrument is not tradable", err: model.ErrInstrumentNotTradable, rejected: true},
+		{name: "price is unavailable", err: fmt.Errorf("getSharePrice: %w", model.ErrPriceUnavailable)},
+		{name: "deadline exceeded", err: fmt.Errorf("CreatePosition: %w", context.DeadlineExceeded)},
+		{name: "database error", err: errors.New("connection reset by peer")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rejected := isOrderRejection(tt.err); rejected != tt.rejected {
+				t.Errorf("isOrderRejection(%v) = %v, want %v", tt.err, rejected, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestApplyTimeInForce(t *testing.T) {
+	location := time.FixedZone("EST", -5*60*60)
+	s := &TradingService{schedule: &model.TradingSchedule{MarketClose: 16 * time.Hour, Location: location}}
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, location)
+	marketClose := time.Date(2024, 3, 4, 16, 0, 0, 0, location)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		timeInForce model.TimeInForce
+		expiresAt   *time.Time
+		want        model.TimeInForce
+		wantExpiry  *time.Time
+		valid       bool
+	}{
+		{name: "default is GTC", want: model.TimeInForceGTC, valid: true},
+		{name: "default with expiry is GTD", expiresAt: &future, want: model.TimeInForceGTD, wantExpiry: &future, valid: true},
+		{name: "GTC ignores expiry", timeInForce: model.TimeInForceGTC, expiresAt: &future, want: model.TimeInForceGTC, valid: true},
+		{name: "IOC", timeInForce: model.TimeInForceIOC, want: model.TimeInForceIOC, valid: true},
+		{name: "GTD", timeInForce: model.TimeInForceGTD, expiresAt: &future, want: model.TimeInForceGTD, wantExpiry: &future, valid: true},
+		{name: "DAY expires at market close", timeInForce: model.TimeInForceDAY, want: model.TimeInForceDAY, wantExpiry: &marketClose, valid: true},
+		{name: "GTD without expiry", timeInForce: model.TimeInForceGTD},
+		{name: "GTD expired", timeInForce: model.TimeInForceGTD, expiresAt: &past},
+		{name: "FOK", timeInForce: model.TimeInForceFOK, want: model.TimeInForceFOK, valid: true},
+		{name: "FOK ignores expiry", timeInForce: model.TimeInForceFOK, expiresAt: &future, want: model.TimeInForceFOK, valid: true},
+		{name: "unknown", timeInForce: "gtx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &model.Order{}
+			err := s.applyTimeInForce(order, tt.timeInForce, tt.expiresAt, now)
+			if !tt.valid {
+				if !errors.Is(err, model.ErrInvalidArgument) {
+					t.Errorf("applyTimeInForce returned %v, want invalid argument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyTimeInForce: %v", err)
+			}
+			if order.TimeInForce != tt.want {
+				t.Errorf("time in force is %q, want %q", order.TimeInForce, tt.want)
+			}
+			switch {
+			case tt.wantExpiry == nil && order.ExpiresAt != nil:
+				t.Errorf("order expires at %v, want no expiry", *order.ExpiresAt)
+			case tt.wantExpiry != nil && (order.ExpiresAt == nil || !order.ExpiresAt.Equal(*tt.wantExpiry)):
+				t.Errorf("order expires at %v, want %v", order.ExpiresAt, *tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestPlaceImmediateOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeInForce model.TimeInForce
+		price       string
+		status      model.OrderStatus
+	}{
+		{name: "IOC is filled", timeInForce: model.TimeInForceIOC, price: "99", status: model.OrderStatusFilled},
+		{name: "IOC expires", timeInForce: model.TimeInForceIOC, price: "101", status: model.OrderStatusExpired},
+		{name: "FOK is filled", timeInForce: model.TimeInForceFOK, price: "99", status: model.OrderStatusFilled},
+		{name: "FOK expires", timeInForce: model.TimeInForceFOK, price: "101", status: model.OrderStatusExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			rps := newMemoryRepository()
+			s, _ := newMemoryService(rps, newMemoryBalance(profileID, dec("1000")), "AAPL", dec(tt.price), 2)
+			order := &model.Order{
+				ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", Type: model.OrderBuyLimit,
+				TriggerPrice: dec("100"), Total: dec("100"), TimeInForce: tt.timeInForce,
+			}
+
+			_, err := s.PlaceOrder(context.Background(), order, false)
+			if err != nil {
+				t.Fatalf("PlaceOrder: %v", err)
+			}
+			if stored := rps.order(order.ID); stored.Status != tt.status {
+				t.Errorf("order is %v, want %v", stored.Status, tt.status)
+			}
+			_, err = rps.GetPositionByID(context.Background(), order.PositionID)
+			if opened := err == nil; opened != (tt.status == model.OrderStatusFilled) {
+				t.Errorf("position is opened: %v, want %v", opened, tt.status == model.OrderStatusFilled)
+			}
+			if pending := len(s.getPendingOrders()); pending != 0 {
+				t.Errorf("%d orders are left in the book, want 0", pending)
+			}
+		})
+	}
+}
+
+// placeFilledBracket function places bracket buy order with take profit at 110 and stop loss at 90 and fills it at 100
+// Returns entry order with its take profit and stop loss exit orders
+func placeFilledBracket(t *testing.T, s *TradingService, profileID uuid.UUID) (entry, takeProfit, stopLoss *model.Order) {
+	entry = &model.Order{
+		ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", Type: model.OrderBuyLimit,
+		TriggerPrice: dec("100"), Total: dec("100"), TakeProfit: dec("110"), StopLoss: dec("90"), TimeInForce: model.TimeInForceGTC,
+	}
+	exits, err := s.PlaceOrder(context.Background(), entry, true)
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if len(exits) != 2 {
+		t.Fatalf("bracket has %d exit orders, want 2", len(exits))
+	}
+	s.matchOrders(context.Background())
+	return entry, exits[0], exits[1]
+}
+
+func TestFillExitOrderCancelsSibling(t *testing.T) {
+	tests := []struct {
+		name        string
+		price       string
+		wantReason  model.CloseReason
+		takeProfit  model.OrderStatus
+		stopLoss    model.OrderStatus
+		wantBalance string
+	}{
+		{name: "take profit", price: "110", wantReason: model.CloseReasonTakeProfit,
+			takeProfit: model.OrderStatusFilled, stopLoss: model.OrderStatusCanceled, wantBalance: "1010"},
+		{name: "stop loss", price: "90", wantReason: model.CloseReasonStopLoss,
+			takeProfit: model.OrderStatusCanceled, stopLoss: model.OrderStatusFilled, wantBalance: "990"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			rps := newMemoryRepository()
+			balance := newMemoryBalance(profileID, dec("1000"))
+			s, prices := newMemoryService(rps, balance, "AAPL", dec("100"), 2)
+			entry, takeProfit, stopLoss := placeFilledBracket(t, s, profileID)
+			if got := rps.order(entry.ID).Status; got != model.OrderStatusFilled {
+				t.Fatalf("entry order is %v, want %v", got, model.OrderStatusFilled)
+			}
+
+			prices.setPrice("AAPL", dec(tt.price))
+			s.matchOrders(context.Background())
+
+			if got := rps.order(takeProfit.ID).Status; got != tt.takeProfit {
+				t.Errorf("take profit order is %v, want %v", got, tt.takeProfit)
+			}
+			if got := rps.order(stopLoss.ID).Status; got != tt.stopLoss {
+				t.Errorf("stop loss order is %v, want %v", got, tt.stopLoss)
+			}
+			position, err := rps.GetPositionByID(context.Background(), entry.PositionID)
+			if err != nil {
+				t.Fatalf("GetPositionByID: %v", err)
+			}
+			if position.Status != model.PositionStatusClosed || position.CloseReason != tt.wantReason {
+				t.Errorf("position is %v by %v, want %v by %v", position.Status, position.CloseReason, model.PositionStatusClosed, tt.wantReason)
+			}
+			if pending := len(s.getPendingOrders()); pending != 0 {
+				t.Errorf("%d orders are left in the book, want 0", pending)
+			}
+			if got := balance.balance(profileID); !got.Equal(dec(tt.wantBalance)) {
+				t.Errorf("balance is %v, want %v", got, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestFillExitOrderWhenSiblingCancelFails(t *testing.T) {
+	profileID := uuid.New()
+	rps := newMemoryRepository()
+	balance := newMemoryBalance(profileID, dec("1000"))
+	s, prices := newMemoryService(rps, balance, "AAPL", dec("100"), 2)
+	_, takeProfit, stopLoss := placeFilledBracket(t, s, profileID)
+	rps.fail("UpdateOrderStatus "+stopLoss.ID.String(), errors.New("connection reset"))
+
+	prices.setPrice("AAPL", dec("110"))
+	s.matchOrders(context.Background())
+	if got := rps.order(takeProfit.ID).Status; got != model.OrderStatusFilled {
+		t.Fatalf("take profit order is %v, want %v", got, model.OrderStatusFilled)
+	}
+	if got := rps.order(stopLoss.ID).Status; got != model.OrderStatusPending {
+		t.Fatalf("stop loss order is %v, want it to stay %v after failed cancel", got, model.OrderStatusPending)
+	}
+	if pending := s.getPendingOrders(); len(pending) != 1 || pending[0].ID != stopLoss.ID {
+		t.Fatalf("book has %d orders, want only stop loss order to be canceled later", len(pending))
+	}
+
+	// stop loss triggered after the position is closed is canceled instead of closing the position again
+	rps.fail("UpdateOrderStatus "+stopLoss.ID.String(), nil)
+	prices.setPrice("AAPL", dec("90"))
+	s.matchOrders(context.Background())
+	if got := rps.order(stopLoss.ID).Status; got != model.OrderStatusCanceled {
+		t.Errorf("stop loss order is %v, want %v", got, model.OrderStatusCanceled)
+	}
+	if pending := len(s.getPendingOrders()); pending != 0 {
+		t.Errorf("%d orders are left in the book, want 0", pending)
+	}
+	if got := balance.balance(profileID); !got.Equal(dec("1010")) {
+		t.Errorf("balance is %v, want 1010 credited by take profit only", got)
+	}
+}
@@ -0,0 +1,201 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// constants of outbox relay
+const (
+	outboxBatchSize     = 100
+	outboxPurgeInterval = time.Minute
+)
+
+// OutboxRepository interface represents outbox methods of trading-service-repository
+type OutboxRepository interface {
+	GetUnpublishedEvents(context.Context, int) ([]*model.OutboxEvent, error)
+	MarkEventsPublished(context.Context, []int64) error
+	DeletePublishedEvents(context.Context, time.Time) (int64, error)
+}
+
+// EventSink interface represents a destination of position lifecycle events
+type EventSink interface {
+	Publish(context.Context, *model.OutboxEvent) error
+}
+
+// OutboxRelay struct represents a worker which publishes outbox events to the sink
+// Delivery is at-least-once: event is marked as published only after sink accepted it
+// Published events are kept during retention and then deleted from outbox
+// Last published sequence of each profile is remembered, so an event after a gap in sequence is held back until the missing one comes
+type OutboxRelay struct {
+	rps           OutboxRepository
+	sink          EventSink
+	retention     time.Duration
+	mu            sync.Mutex
+	lastSequences map[uuid.UUID]int64
+}
+
+// NewOutboxRelay creates a new OutboxRelay
+func NewOutboxRelay(rps OutboxRepository, sink EventSink, retention time.Duration) *OutboxRelay {
+	return &OutboxRelay{rps: rps, sink: sink, retention: retention, lastSequences: make(map[uuid.UUID]int64)}
+}
+
+// Run method relays outbox events with given interval and purges published ones until context is done
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(outboxPurgeInterval)
+	defer purgeTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := r.relay(ctx)
+			if err != nil {
+				logrus.Errorf("relay: %v", err)
+			}
+		case now := <-purgeTicker.C:
+			err := r.purge(ctx, now)
+			if err != nil {
+				logrus.Errorf("purge: %v", err)
+			}
+		}
+	}
+}
+
+// purge method deletes events whose retention is over by given time
+func (r *OutboxRelay) purge(ctx context.Context, now time.Time) error {
+	_, err := r.rps.DeletePublishedEvents(ctx, now.Add(-r.retention))
+	if err != nil {
+		return fmt.Errorf("DeletePublishedEvents: %w", err)
+	}
+	return nil
+}
+
+// relay method publishes one batch of unpublished events in order of their sequence within each profile
+// If an event of a profile fails or follows a gap in sequence, next events of the same profile are held back to keep their order
+// Event whose sequence is already published is only marked as published, as it is left by failed marking of previous batch
+func (r *OutboxRelay) relay(ctx context.Context) error {
+	events, err := r.rps.GetUnpublishedEvents(ctx, outboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("GetUnpublishedEvents: %w", err)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Sequence < events[j].Sequence
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	heldProfiles := make(map[uuid.UUID]bool)
+	published := make([]int64, 0, len(events))
+	for _, event := range events {
+		if heldProfiles[event.ProfileID] {
+			continue
+		}
+		// sequence of a profile which is not relayed since start is not known, so its first event is not checked for a gap
+		last, known := r.lastSequences[event.ProfileID]
+		switch {
+		case known && event.Sequence <= last:
+			published = append(published, event.ID)
+			continue
+		case known && event.Sequence > last+1:
+			logrus.WithFields(logrus.Fields{"EventID": event.ID, "ProfileID": event.ProfileID, "Sequence": event.Sequence}).
+				Warnf("event is held back until event %d is committed", last+1)
+			heldProfiles[event.ProfileID] = true
+			continue
+		}
+		err = r.sink.Publish(ctx, event)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"EventID": event.ID, "Type": event.Type, "ProfileID": event.ProfileID}).Errorf("Publish: %v", err)
+			heldProfiles[event.ProfileID] = true
+			continue
+		}
+		r.lastSequences[event.ProfileID] = event.Sequence
+		published = append(published, event.ID)
+	}
+	if len(published) == 0 {
+		return nil
+	}
+	err = r.rps.MarkEventsPublished(ctx, published)
+	if err != nil {
+		return fmt.Errorf("MarkEventsPublished: %w", err)
+	}
+	return nil
+}
+
+// newOutboxEvent function creates an outbox event of the position with given payload
+func newOutboxEvent(eventType model.OutboxEventType, position *model.Position, payload interface{}) (*model.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Marshal: %w", err)
+	}
+	return &model.OutboxEvent{
+		Type:       eventType,
+		ProfileID:  position.ProfileID,
+		PositionID: position.ID,
+		Payload:    data,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// newOpenedEvents function creates outbox events of opened position
+func newOpenedEvents(position *model.Position) ([]*model.OutboxEvent, error) {
+	event, err := newOutboxEvent(model.EventPositionOpened, position, position)
+	if err != nil {
+		return nil, fmt.Errorf("newOutboxEvent: %w", err)
+	}
+	return []*model.OutboxEvent{event}, nil
+}
+
+// newClosedEvents function creates outbox events of closed position, StopTriggered event goes first for positions closed by trigger
+func newClosedEvents(position *model.Position, sharePrice, PnL decimal.Decimal, reason model.CloseReason) ([]*model.OutboxEvent, error) {
+	var events []*model.OutboxEvent
+	if reason == model.CloseReasonStopLoss || reason == model.CloseReasonTakeProfit {
+		event, err := newOutboxEvent(model.EventStopTriggered, position, newTriggerEvent(position, reason, sharePrice, PnL))
+		if err != nil {
+			return nil, fmt.Errorf("newOutboxEvent: %w", err)
+		}
+		events = append(events, event)
+	}
+	event, err := newOutboxEvent(model.EventPositionClosed, position, &model.ClosedPositionPayload{
+		Position:   position,
+		SharePrice: sharePrice,
+		PnL:        PnL,
+		NetPnL:     newProfitAndLoss(position).NetPercent,
+		Commission: position.OpenFee.Add(position.CloseFee),
+		Reason:     reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("newOutboxEvent: %w", err)
+	}
+	return append(events, event), nil
+}
+
+// newTriggerEvent function creates a trigger event of position closed by stop loss or take profit
+func newTriggerEvent(position *model.Position, reason model.CloseReason, executionPrice, PnL decimal.Decimal) *model.TriggerEvent {
+	triggerPrice := position.StopLoss
+	if reason == model.CloseReasonTakeProfit {
+		triggerPrice = position.TakeProfit
+	}
+	return &model.TriggerEvent{
+		PositionID:     position.ID,
+		ProfileID:      position.ProfileID,
+		ShareName:      position.ShareName,
+		Reason:         reason,
+		TriggerPrice:   triggerPrice,
+		ExecutionPrice: executionPrice,
+		PnL:            PnL,
+		TriggeredAt:    time.Now(),
+	}
+}
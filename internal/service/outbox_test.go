@@ -0,0 +1,233 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// memoryOutbox struct is an in-memory outbox repository which returns unpublished events in order they were added
+type memoryOutbox struct {
+	mu        sync.Mutex
+	events    []*model.OutboxEvent
+	published map[int64]time.Time
+	now       time.Time
+}
+
+// newMemoryOutbox creates a new memoryOutbox with given events
+func newMemoryOutbox(events ...*model.OutboxEvent) *memoryOutbox {
+	return &memoryOutbox{events: events, published: make(map[int64]time.Time), now: time.Now()}
+}
+
+// add method adds events committed after the previous ones
+func (o *memoryOutbox) add(events ...*model.OutboxEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, events...)
+}
+
+// GetUnpublishedEvents method returns unpublished events in order they were added
+func (o *memoryOutbox) GetUnpublishedEvents(_ context.Context, limit int) ([]*model.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var events []*model.OutboxEvent
+	for _, event := range o.events {
+		if _, ok := o.published[event.ID]; !ok && len(events) < limit {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
+
+// MarkEventsPublished method marks events as published at current time of the outbox
+func (o *memoryOutbox) MarkEventsPublished(_ context.Context, IDs []int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, ID := range IDs {
+		o.published[ID] = o.now
+	}
+	return nil
+}
+
+// DeletePublishedEvents method deletes events published before given time
+func (o *memoryOutbox) DeletePublishedEvents(_ context.Context, before time.Time) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var deleted int64
+	kept := o.events[:0]
+	for _, event := range o.events {
+		if publishedAt, ok := o.published[event.ID]; ok && publishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	o.events = kept
+	return deleted, nil
+}
+
+// isPublished method checks if the event of given ID is marked as published
+func (o *memoryOutbox) isPublished(ID int64) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.published[ID]
+	return ok
+}
+
+// memorySink struct is an event sink which records published events and fails events of given IDs
+type memorySink struct {
+	mu        sync.Mutex
+	published []*model.OutboxEvent
+	failures  map[int64]error
+}
+
+// Publish method records the event
+func (s *memorySink) Publish(_ context.Context, event *model.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failures[event.ID]; err != nil {
+		return err
+	}
+	s.published = append(s.published, event)
+	return nil
+}
+
+// sequences method returns published sequences of the profile in order of publishing
+func (s *memorySink) sequences(profileID uuid.UUID) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sequences []int64
+	for _, event := range s.published {
+		if event.ProfileID == profileID {
+			sequences = append(sequences, event.Sequence)
+		}
+	}
+	return sequences
+}
+
+// newSequencedEvent function creates an event of the profile with given ID and sequence
+func newSequencedEvent(ID int64, profileID uuid.UUID, sequence int64) *model.OutboxEvent {
+	return &model.OutboxEvent{ID: ID, Type: model.EventPositionOpened, ProfileID: profileID, Sequence: sequence, CreatedAt: time.Now()}
+}
+
+// relayOnce function runs one relay of the batch and fails the test on error
+func relayOnce(t *testing.T, relay *OutboxRelay) {
+	err := relay.relay(context.Background())
+	if err != nil {
+		t.Fatalf("relay: %v", err)
+	}
+}
+
+// checkSequences function checks published sequences of the profile
+func checkSequences(t *testing.T, sink *memorySink, profileID uuid.UUID, want ...int64) {
+	if got := sink.sequences(profileID); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("published sequences are %v, want %v", got, want)
+	}
+}
+
+func TestOutboxRelayPublishesInSequenceOrder(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	// events of the profiles come out of their order
+	outbox := newMemoryOutbox(
+		newSequencedEvent(1, first, 2),
+		newSequencedEvent(2, second, 1),
+		newSequencedEvent(3, first, 1),
+		newSequencedEvent(4, second, 3),
+		newSequencedEvent(5, second, 2),
+	)
+	sink := &memorySink{}
+	relayOnce(t, NewOutboxRelay(outbox, sink, time.Hour))
+
+	checkSequences(t, sink, first, 1, 2)
+	checkSequences(t, sink, second, 1, 2, 3)
+	for ID := int64(1); ID <= 5; ID++ {
+		if !outbox.isPublished(ID) {
+			t.Errorf("event %d is not marked as published", ID)
+		}
+	}
+}
+
+func TestOutboxRelayHoldsBackGap(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	outbox := newMemoryOutbox(newSequencedEvent(1, first, 1))
+	sink := &memorySink{}
+	relay := NewOutboxRelay(outbox, sink, time.Hour)
+	relayOnce(t, relay)
+
+	// event of sequence 2 is not committed yet
+	outbox.add(newSequencedEvent(3, first, 3), newSequencedEvent(4, second, 1))
+	relayOnce(t, relay)
+	checkSequences(t, sink, first, 1)
+	checkSequences(t, sink, second, 1)
+	if outbox.isPublished(3) {
+		t.Errorf("event after the gap is marked as published")
+	}
+
+	outbox.add(newSequencedEvent(2, first, 2))
+	relayOnce(t, relay)
+	checkSequences(t, sink, first, 1, 2, 3)
+}
+
+func TestOutboxRelayWithFailingSink(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	outbox := newMemoryOutbox(
+		newSequencedEvent(1, first, 1),
+		newSequencedEvent(2, second, 1),
+		newSequencedEvent(3, first, 2),
+	)
+	sink := &memorySink{failures: map[int64]error{1: errors.New("sink is unavailable")}}
+	relay := NewOutboxRelay(outbox, sink, time.Hour)
+	relayOnce(t, relay)
+
+	// failed event holds back next events of its profile only
+	checkSequences(t, sink, first)
+	checkSequences(t, sink, second, 1)
+	if outbox.isPublished(1) || outbox.isPublished(3) {
+		t.Errorf("events of failed profile are marked as published")
+	}
+
+	delete(sink.failures, 1)
+	relayOnce(t, relay)
+	checkSequences(t, sink, first, 1, 2)
+	checkSequences(t, sink, second, 1)
+}
+
+func TestOutboxRelayPurge(t *testing.T) {
+	profileID := uuid.New()
+	outbox := newMemoryOutbox(newSequencedEvent(1, profileID, 1), newSequencedEvent(2, profileID, 2))
+	relay := NewOutboxRelay(outbox, &memorySink{}, time.Hour)
+	relayOnce(t, relay)
+	outbox.add(newSequencedEvent(3, profileID, 3))
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{name: "within retention", now: outbox.now.Add(time.Hour), want: 3},
+		{name: "after retention", now: outbox.now.Add(time.Hour + time.Second), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := relay.purge(context.Background(), tt.now)
+			if err != nil {
+				t.Fatalf("purge: %v", err)
+			}
+			if got := len(outbox.events); got != tt.want {
+				t.Errorf("%d events are kept, want %d", got, tt.want)
+			}
+		})
+	}
+	if outbox.events[0].ID != 3 {
+		t.Errorf("event %d is kept, want unpublished event 3", outbox.events[0].ID)
+	}
+}
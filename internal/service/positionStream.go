@@ -0,0 +1,290 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// positionUpdatesBuffer is a size of subscriber's buffer of position lifecycle updates
+const positionUpdatesBuffer = 100
+
+// StreamPositions method sends live updates of all opened positions of given profile until context is done
+// Price updates are sent on every tick of price service, lifecycle updates are sent when positions are opened or closed
+func (s *TradingService) StreamPositions(ctx context.Context, profileID uuid.UUID, send func(*model.PositionUpdate) error) error {
+	subscriberID, updates := s.subscribeToPositionUpdates(profileID)
+	defer s.unsubscribeFromPositionUpdates(profileID, subscriberID)
+
+	openedPositions := s.getProfileOpenedPositions(profileID)
+
+	prices, cancelPrices, err := s.subscribeToSharesOf(ctx, openedPositions)
+	if err != nil {
+		return fmt.Errorf("subscribeToSharesOf: %w", err)
+	}
+	defer func() { cancelPrices() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-updates:
+			err = send(update)
+			if err != nil {
+				return fmt.Errorf("send: %w", err)
+			}
+			sharesChanged := applyPositionUpdate(openedPositions, update)
+			if !sharesChanged {
+				continue
+			}
+			cancelPrices()
+			prices, cancelPrices, err = s.subscribeToSharesOf(ctx, openedPositions)
+			if err != nil {
+				return fmt.Errorf("subscribeToSharesOf: %w", err)
+			}
+		case shares, ok := <-prices:
+			if !ok {
+				return fmt.Errorf("price stream of profile %v is closed", profileID)
+			}
+			for _, share := range shares {
+				for _, openedPosition := range openedPositions {
+					if openedPosition.ShareName != share.ShareName {
+						continue
+					}
+					s.refreshOpenedPosition(openedPosition)
+					markPrice := s.precision(share.ShareName).Price(share.SharePrice)
+					err = send(newPriceUpdate(openedPosition, markPrice, s.rounding))
+					if err != nil {
+						return fmt.Errorf("send: %w", err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// subscribeToSharesOf method subscribes to prices of all shares of given positions, returns nil channel if there are no positions
+// Returned cancel function stops the subscription
+func (s *TradingService) subscribeToSharesOf(ctx context.Context, openedPositions map[uuid.UUID]*model.OpenedPosition) (<-chan []*model.Share, context.CancelFunc, error) {
+	shareNames := make(map[string]bool)
+	selectedShares := make([]string, 0, len(openedPositions))
+	for _, openedPosition := range openedPositions {
+		if !shareNames[openedPosition.ShareName] {
+			shareNames[openedPosition.ShareName] = true
+			selectedShares = append(selectedShares, openedPosition.ShareName)
+		}
+	}
+	priceCtx, cancel := context.WithCancel(ctx)
+	if len(selectedShares) == 0 {
+		return nil, cancel, nil
+	}
+	prices, err := s.priceServiceRps.SubscribeShares(priceCtx, selectedShares)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("SubscribeShares: %w", err)
+	}
+	return prices, cancel, nil
+}
+
+// applyPositionUpdate function applies lifecycle update to the set of watched positions, returns true if set of shares was changed
+func applyPositionUpdate(openedPositions map[uuid.UUID]*model.OpenedPosition, update *model.PositionUpdate) bool {
+	hasShare := func(shareName string) bool {
+		for _, openedPosition := range openedPositions {
+			if openedPosition.ShareName == shareName {
+				return true
+			}
+		}
+		return false
+	}
+	switch update.Type {
+	case model.PositionUpdateOpened:
+		isNewShare := !hasShare(update.ShareName)
+		openedPositions[update.PositionID] = &model.OpenedPosition{
+			PositionID:      update.PositionID,
+			ProfileID:       update.ProfileID,
+			ShareName:       update.ShareName,
+			IsLong:          update.IsLong,
+			ShareOpenPrice:  update.SharePrice,
+			ShareClosePrice: update.StopLoss,
+			TakeProfit:      update.TakeProfit,
+			ShareAmount:     update.ShareAmount,
+			IsOpened:        true,
+		}
+		return isNewShare
+	case model.PositionUpdateReduced, model.PositionUpdateIncreased:
+		if openedPosition, ok := openedPositions[update.PositionID]; ok {
+			openedPosition.ShareOpenPrice = update.SharePrice
+			openedPosition.ShareAmount = update.ShareAmount
+		}
+		return false
+	case model.PositionUpdateClosed:
+		if _, ok := openedPositions[update.PositionID]; !ok {
+			return false
+		}
+		delete(openedPositions, update.PositionID)
+		return !hasShare(update.ShareName)
+	}
+	return false
+}
+
+// refreshOpenedPosition method copies current stop loss level and margin of the position from position manager
+// Trailing stop moves with the price and margin is not a part of lifecycle updates
+func (s *TradingService) refreshOpenedPosition(openedPosition *model.OpenedPosition) {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	if managed, ok := s.positionManager.OpenedPositions[openedPosition.ProfileID][openedPosition.PositionID]; ok {
+		openedPosition.ShareClosePrice = managed.ShareClosePrice
+		openedPosition.Margin = managed.Margin
+		openedPosition.Leverage = managed.Leverage
+	}
+}
+
+// getProfileOpenedPositions method returns a snapshot of opened positions of given profile
+func (s *TradingService) getProfileOpenedPositions(profileID uuid.UUID) map[uuid.UUID]*model.OpenedPosition {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	openedPositions := make(map[uuid.UUID]*model.OpenedPosition, len(s.positionManager.OpenedPositions[profileID]))
+	for positionID, openedPosition := range s.positionManager.OpenedPositions[profileID] {
+		copied := *openedPosition
+		openedPositions[positionID] = &copied
+	}
+	return openedPositions
+}
+
+// subscribeToPositionUpdates method registers a new subscriber of lifecycle updates of given profile
+func (s *TradingService) subscribeToPositionUpdates(profileID uuid.UUID) (uuid.UUID, <-chan *model.PositionUpdate) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	subscriberID := uuid.New()
+	updates := make(chan *model.PositionUpdate, positionUpdatesBuffer)
+	if _, ok := s.positionManager.Subscribers[profileID]; !ok {
+		s.positionManager.Subscribers[profileID] = make(map[uuid.UUID]chan *model.PositionUpdate)
+	}
+	s.positionManager.Subscribers[profileID][subscriberID] = updates
+	return subscriberID, updates
+}
+
+// unsubscribeFromPositionUpdates method removes the subscriber of lifecycle updates of given profile
+func (s *TradingService) unsubscribeFromPositionUpdates(profileID, subscriberID uuid.UUID) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	delete(s.positionManager.Subscribers[profileID], subscriberID)
+	if len(s.positionManager.Subscribers[profileID]) == 0 {
+		delete(s.positionManager.Subscribers, profileID)
+	}
+}
+
+// publishPositionUpdate method sends lifecycle update to all subscribers of position's profile
+func (s *TradingService) publishPositionUpdate(update *model.PositionUpdate) {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	for subscriberID, updates := range s.positionManager.Subscribers[update.ProfileID] {
+		select {
+		case updates <- update:
+		default:
+			logrus.WithFields(logrus.Fields{"SubscriberID": subscriberID, "PositionID": update.PositionID}).Warn("subscriber is too slow, position update dropped")
+		}
+	}
+}
+
+// newOpenedUpdate function creates a lifecycle update of opened position
+func newOpenedUpdate(position *model.Position) *model.PositionUpdate {
+	return &model.PositionUpdate{
+		Type:        model.PositionUpdateOpened,
+		PositionID:  position.ID,
+		ProfileID:   position.ProfileID,
+		ShareName:   position.ShareName,
+		IsLong:      position.IsLong,
+		SharePrice:  position.SharePrice,
+		MarkPrice:   position.SharePrice,
+		ShareAmount: position.ShareAmount,
+		StopLoss:    position.StopLoss,
+		TakeProfit:  position.TakeProfit,
+	}
+}
+
+// newClosedUpdate function creates a lifecycle update of closed position
+func newClosedUpdate(position *model.Position, sharePrice, PnL decimal.Decimal, reason model.CloseReason) *model.PositionUpdate {
+	return &model.PositionUpdate{
+		Type:        model.PositionUpdateClosed,
+		PositionID:  position.ID,
+		ProfileID:   position.ProfileID,
+		ShareName:   position.ShareName,
+		IsLong:      position.IsLong,
+		SharePrice:  position.SharePrice,
+		MarkPrice:   sharePrice,
+		ShareAmount: position.ShareAmount,
+		StopLoss:    position.StopLoss,
+		TakeProfit:  position.TakeProfit,
+		CloseReason: reason,
+		PnL:         PnL,
+	}
+}
+
+// newReducedUpdate function creates a lifecycle update of partially closed position with its remaining amount of shares
+func newReducedUpdate(remaining *model.Position, sharePrice, PnL decimal.Decimal) *model.PositionUpdate {
+	update := newClosedUpdate(remaining, sharePrice, PnL, model.CloseReasonManual)
+	update.Type = model.PositionUpdateReduced
+	return update
+}
+
+// newIncreasedUpdate function creates a lifecycle update of increased position with its new open price and amount of shares
+func newIncreasedUpdate(position *model.Position) *model.PositionUpdate {
+	update := newOpenedUpdate(position)
+	update.Type = model.PositionUpdateIncreased
+	return update
+}
+
+// newPriceUpdate function creates a price update of opened position by given mark price
+func newPriceUpdate(openedPosition *model.OpenedPosition, markPrice decimal.Decimal, rounding *model.RoundingRules) *model.PositionUpdate {
+	position := &model.Position{
+		IsLong:      openedPosition.IsLong,
+		SharePrice:  openedPosition.ShareOpenPrice,
+		ShareAmount: openedPosition.ShareAmount,
+		Total:       openedPosition.Margin,
+	}
+	if position.Total.IsZero() {
+		position.Total = openedPosition.ShareOpenPrice.Mul(openedPosition.ShareAmount)
+	}
+	PnL, PnLPercent := calculateUnrealizedPnL(position, markPrice, rounding)
+	stopLossDistance, takeProfitDistance := calculateDistanceToLevels(openedPosition, markPrice)
+	return &model.PositionUpdate{
+		Type:                 model.PositionUpdatePrice,
+		PositionID:           openedPosition.PositionID,
+		ProfileID:            openedPosition.ProfileID,
+		ShareName:            openedPosition.ShareName,
+		IsLong:               openedPosition.IsLong,
+		SharePrice:           openedPosition.ShareOpenPrice,
+		MarkPrice:            markPrice,
+		ShareAmount:          openedPosition.ShareAmount,
+		UnrealizedPnL:        PnL,
+		UnrealizedPnLPercent: PnLPercent,
+		StopLoss:             openedPosition.ShareClosePrice,
+		TakeProfit:           openedPosition.TakeProfit,
+		StopLossDistance:     stopLossDistance,
+		TakeProfitDistance:   takeProfitDistance,
+	}
+}
+
+// calculateDistanceToLevels function calculates how far mark price is from stop loss and take profit in direction of the position
+// Distance is positive until the level is reached, it is zero when the level is not set
+func calculateDistanceToLevels(openedPosition *model.OpenedPosition, markPrice decimal.Decimal) (stopLossDistance, takeProfitDistance decimal.Decimal) {
+	if openedPosition.ShareClosePrice.IsPositive() {
+		stopLossDistance = markPrice.Sub(openedPosition.ShareClosePrice)
+		if !openedPosition.IsLong {
+			stopLossDistance = stopLossDistance.Neg()
+		}
+	}
+	if openedPosition.TakeProfit.IsPositive() {
+		takeProfitDistance = openedPosition.TakeProfit.Sub(markPrice)
+		if !openedPosition.IsLong {
+			takeProfitDistance = takeProfitDistance.Neg()
+		}
+	}
+	return stopLossDistance, takeProfitDistance
+}
@@ -0,0 +1,83 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func TestApplyPositionUpdate(t *testing.T) {
+	profileID := uuid.New()
+	apple, secondApple, tesla := uuid.New(), uuid.New(), uuid.New()
+	openedPositions := make(map[uuid.UUID]*model.OpenedPosition)
+	steps := []struct {
+		name          string
+		update        *model.PositionUpdate
+		sharesChanged bool
+		watched       int
+	}{
+		{
+			name:          "first position of a share",
+			update:        &model.PositionUpdate{Type: model.PositionUpdateOpened, PositionID: apple, ProfileID: profileID, ShareName: "AAPL", SharePrice: dec("10"), ShareAmount: dec("5")},
+			sharesChanged: true,
+			watched:       1,
+		},
+		{
+			name:    "second position of the same share",
+			update:  &model.PositionUpdate{Type: model.PositionUpdateOpened, PositionID: secondApple, ProfileID: profileID, ShareName: "AAPL", SharePrice: dec("11"), ShareAmount: dec("1")},
+			watched: 2,
+		},
+		{
+			name:          "position of another share",
+			update:        &model.PositionUpdate{Type: model.PositionUpdateOpened, PositionID: tesla, ProfileID: profileID, ShareName: "TSLA", SharePrice: dec("200"), ShareAmount: dec("1")},
+			sharesChanged: true,
+			watched:       3,
+		},
+		{
+			name:    "increased position",
+			update:  &model.PositionUpdate{Type: model.PositionUpdateIncreased, PositionID: apple, ShareName: "AAPL", SharePrice: dec("10.5"), ShareAmount: dec("10")},
+			watched: 3,
+		},
+		{
+			name:    "price update",
+			update:  &model.PositionUpdate{Type: model.PositionUpdatePrice, PositionID: apple, ShareName: "AAPL", MarkPrice: dec("12")},
+			watched: 3,
+		},
+		{
+			name:    "closed position with another position of the share",
+			update:  &model.PositionUpdate{Type: model.PositionUpdateClosed, PositionID: secondApple, ShareName: "AAPL"},
+			watched: 2,
+		},
+		{
+			name:    "closed position which is not watched",
+			update:  &model.PositionUpdate{Type: model.PositionUpdateClosed, PositionID: uuid.New(), ShareName: "MSFT"},
+			watched: 2,
+		},
+		{
+			name:          "closed last position of a share",
+			update:        &model.PositionUpdate{Type: model.PositionUpdateClosed, PositionID: tesla, ShareName: "TSLA"},
+			sharesChanged: true,
+			watched:       1,
+		},
+	}
+	// steps are applied one after another to the same set of positions
+	for _, step := range steps {
+		sharesChanged := applyPositionUpdate(openedPositions, step.update)
+		if sharesChanged != step.sharesChanged {
+			t.Errorf("%s: shares changed is %v, want %v", step.name, sharesChanged, step.sharesChanged)
+		}
+		if len(openedPositions) != step.watched {
+			t.Errorf("%s: %d positions are watched, want %d", step.name, len(openedPositions), step.watched)
+		}
+	}
+	openedPosition, ok := openedPositions[apple]
+	if !ok {
+		t.Fatal("increased position is not watched")
+	}
+	if !openedPosition.ShareOpenPrice.Equal(dec("10.5")) || !openedPosition.ShareAmount.Equal(dec("10")) {
+		t.Errorf("increased position has %v shares by %v, want 10 shares by 10.5", openedPosition.ShareAmount, openedPosition.ShareOpenPrice)
+	}
+}
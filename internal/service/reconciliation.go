@@ -0,0 +1,105 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// sharePricesTimeout is a time during which prices of all shares of positions are awaited by reconciliation
+const sharePricesTimeout = 5 * time.Second
+
+// RestorePositions method loads all positions from database into position manager, it must be called before serving requests
+func (s *TradingService) RestorePositions(ctx context.Context) (*model.ReconciliationReport, error) {
+	report, err := s.reconcile(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: %w", err)
+	}
+	return report, nil
+}
+
+// ReconcilePositions method compares positions in database with position manager without changing any of them
+func (s *TradingService) ReconcilePositions(ctx context.Context) (*model.ReconciliationReport, error) {
+	report, err := s.reconcile(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: %w", err)
+	}
+	return report, nil
+}
+
+// reconcile method builds a reconciliation report, positions missing in position manager are added to it if restore is true
+// Positions which are being opened or closed at the moment may be reported as well, so restore is only safe on startup
+func (s *TradingService) reconcile(ctx context.Context, restore bool) (*model.ReconciliationReport, error) {
+	positions, err := s.rps.GetAllPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllPositions: %w", err)
+	}
+	report := &model.ReconciliationReport{}
+	inDatabase := make(map[uuid.UUID]bool, len(positions))
+	shareNames := make(map[string]bool)
+	for _, position := range positions {
+		inDatabase[position.ID] = true
+		shareNames[position.ShareName] = true
+		if s.isPositionInManager(position.ProfileID, position.ID) {
+			continue
+		}
+		if !restore {
+			report.OnlyInDatabase = append(report.OnlyInDatabase, position.ID)
+			continue
+		}
+		err = s.addPositionToMap(position.ProfileID, position)
+		if err != nil {
+			report.OnlyInDatabase = append(report.OnlyInDatabase, position.ID)
+			continue
+		}
+		report.Restored = append(report.Restored, position.ID)
+	}
+	for _, openedPosition := range s.getOpenedPositions() {
+		if !inDatabase[openedPosition.PositionID] {
+			report.OnlyInManager = append(report.OnlyInManager, openedPosition.PositionID)
+		}
+	}
+	report.UnavailableShares = s.getUnavailableShares(ctx, shareNames)
+	return report, nil
+}
+
+// getUnavailableShares method requests prices of all given shares at once and returns shares without price
+// Prices are awaited concurrently, so reconciliation waits sharePricesTimeout at most whatever the number of shares
+func (s *TradingService) getUnavailableShares(ctx context.Context, shareNames map[string]bool) []string {
+	ctx, cancel := context.WithTimeout(ctx, sharePricesTimeout)
+	defer cancel()
+
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		unavailable []string
+	)
+	for shareName := range shareNames {
+		wg.Add(1)
+		go func(shareName string) {
+			defer wg.Done()
+			_, err := s.priceServiceRps.AddSubscriber(ctx, []string{shareName})
+			if err != nil {
+				mu.Lock()
+				unavailable = append(unavailable, shareName)
+				mu.Unlock()
+			}
+		}(shareName)
+	}
+	wg.Wait()
+	return unavailable
+}
+
+// isPositionInManager method checks if position is present in position manager
+func (s *TradingService) isPositionInManager(profileID, positionID uuid.UUID) bool {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	_, ok := s.positionManager.OpenedPositions[profileID][positionID]
+	return ok
+}
@@ -0,0 +1,53 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+)
+
+// slowPriceService struct is a price service which answers after delay and has no prices of unavailable shares
+type slowPriceService struct {
+	PriceServiceRepository
+	delay       time.Duration
+	unavailable map[string]bool
+}
+
+// AddSubscriber method returns price of the share after delay
+func (p *slowPriceService) AddSubscriber(ctx context.Context, selectedShares []string) (*model.Share, error) {
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if p.unavailable[selectedShares[0]] {
+		return nil, errors.New("price is unavailable")
+	}
+	return &model.Share{ShareName: selectedShares[0], SharePrice: dec("1")}, nil
+}
+
+func TestGetUnavailableSharesWaitsConcurrently(t *testing.T) {
+	delay := 200 * time.Millisecond
+	s := &TradingService{priceServiceRps: &slowPriceService{
+		delay:       delay,
+		unavailable: map[string]bool{"MSFT": true, "TSLA": true},
+	}}
+	shareNames := map[string]bool{"AAPL": true, "MSFT": true, "TSLA": true, "NVDA": true}
+
+	start := time.Now()
+	unavailable := s.getUnavailableShares(context.Background(), shareNames)
+	elapsed := time.Since(start)
+
+	sort.Strings(unavailable)
+	if len(unavailable) != 2 || unavailable[0] != "MSFT" || unavailable[1] != "TSLA" {
+		t.Errorf("unavailable shares are %v, want [MSFT TSLA]", unavailable)
+	}
+	if elapsed >= 2*delay {
+		t.Errorf("prices of %d shares were awaited for %v, want less than %v", len(shareNames), elapsed, 2*delay)
+	}
+}
@@ -0,0 +1,194 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// GetRiskLimits method returns risk limits of the profile, default limits apply if the profile has no own ones
+func (s *TradingService) GetRiskLimits(ctx context.Context, profileID uuid.UUID) (*model.RiskLimits, error) {
+	limits, err := s.rps.GetRiskLimits(ctx, profileID)
+	if errors.Is(err, model.ErrRiskLimitsNotFound) {
+		defaultLimits := *s.riskLimits
+		defaultLimits.ProfileID = profileID
+		return &defaultLimits, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetRiskLimits: %w", err)
+	}
+	return limits, nil
+}
+
+// SetRiskLimits method validates and stores own risk limits of the profile
+func (s *TradingService) SetRiskLimits(ctx context.Context, limits *model.RiskLimits) error {
+	err := validateRiskLimits(limits)
+	if err != nil {
+		return fmt.Errorf("validateRiskLimits: %w", err)
+	}
+	limits.UpdatedAt = time.Now()
+	err = s.rps.SetRiskLimits(ctx, limits)
+	if err != nil {
+		return fmt.Errorf("SetRiskLimits: %w", err)
+	}
+	return nil
+}
+
+// profileLocks struct serializes trades of each profile, so a trade is checked against risk limits only after
+// the previous trade of the profile has changed its opened positions
+type profileLocks struct {
+	mu    sync.Mutex
+	locks map[uuid.UUID]*profileLock
+}
+
+// profileLock struct represents a lock of one profile and the number of trades holding or waiting for it
+type profileLock struct {
+	mu    sync.Mutex
+	users int
+}
+
+// newProfileLocks creates a new profileLocks
+func newProfileLocks() *profileLocks {
+	return &profileLocks{locks: make(map[uuid.UUID]*profileLock)}
+}
+
+// lock method locks the profile and returns a function unlocking it, lock of a profile is dropped when nobody uses it
+func (l *profileLocks) lock(profileID uuid.UUID) func() {
+	l.mu.Lock()
+	profile, ok := l.locks[profileID]
+	if !ok {
+		profile = &profileLock{}
+		l.locks[profileID] = profile
+	}
+	profile.users++
+	l.mu.Unlock()
+
+	profile.mu.Lock()
+	return func() {
+		profile.mu.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		profile.users--
+		if profile.users == 0 {
+			delete(l.locks, profileID)
+		}
+	}
+}
+
+// checkRiskLimits method checks a trade of given notional against risk limits of the profile before it is made
+// Opening of a new position also counts against maximum of open positions, increasing of a position does not
+// Caller must hold trade lock of the profile until the trade changes opened positions
+func (s *TradingService) checkRiskLimits(ctx context.Context, profileID uuid.UUID, shareName string, notional decimal.Decimal, newPosition bool) error {
+	limits, err := s.GetRiskLimits(ctx, profileID)
+	if err != nil {
+		return fmt.Errorf("GetRiskLimits: %w", err)
+	}
+	exposure := s.getRiskExposure(profileID, shareName)
+	if limits.MaxDailyLoss.IsPositive() {
+		realizedPnL, err := s.rps.GetRealizedPnL(ctx, profileID, dayStart(time.Now(), s.schedule.Location))
+		if err != nil {
+			return fmt.Errorf("GetRealizedPnL: %w", err)
+		}
+		if realizedPnL.IsNegative() {
+			exposure.DailyLoss = realizedPnL.Neg()
+		}
+	}
+	violations := evaluateRiskLimits(limits, exposure, shareName, notional, newPosition)
+	if len(violations) > 0 {
+		return &model.RiskLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// getRiskExposure method counts opened positions of the profile and their notional by open prices
+func (s *TradingService) getRiskExposure(profileID uuid.UUID, shareName string) *model.RiskExposure {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	exposure := &model.RiskExposure{OpenPositions: len(s.positionManager.OpenedPositions[profileID])}
+	for _, openedPosition := range s.positionManager.OpenedPositions[profileID] {
+		notional := openedPosition.ShareOpenPrice.Mul(openedPosition.ShareAmount)
+		exposure.TotalExposure = exposure.TotalExposure.Add(notional)
+		if openedPosition.ShareName == shareName {
+			exposure.InstrumentNotional = exposure.InstrumentNotional.Add(notional)
+		}
+	}
+	return exposure
+}
+
+// evaluateRiskLimits function returns all the risk limits which a trade of given notional would break
+func evaluateRiskLimits(limits *model.RiskLimits, exposure *model.RiskExposure, shareName string, notional decimal.Decimal, newPosition bool) []model.RiskViolation {
+	var violations []model.RiskViolation
+	profile := limits.ProfileID.String()
+	if limits.MaxOrderSize.IsPositive() && notional.GreaterThan(limits.MaxOrderSize) {
+		violations = append(violations, model.RiskViolation{
+			Rule: model.RiskRuleMaxOrderSize, Subject: shareName, Limit: limits.MaxOrderSize, Value: notional,
+		})
+	}
+	if newPosition && limits.MaxOpenPositions > 0 && exposure.OpenPositions >= limits.MaxOpenPositions {
+		violations = append(violations, model.RiskViolation{
+			Rule: model.RiskRuleMaxOpenPositions, Subject: profile,
+			Limit: decimal.NewFromInt(int64(limits.MaxOpenPositions)), Value: decimal.NewFromInt(int64(exposure.OpenPositions + 1)),
+		})
+	}
+	instrumentNotional := exposure.InstrumentNotional.Add(notional)
+	if limits.MaxInstrumentNotional.IsPositive() && instrumentNotional.GreaterThan(limits.MaxInstrumentNotional) {
+		violations = append(violations, model.RiskViolation{
+			Rule: model.RiskRuleMaxInstrumentNotional, Subject: shareName, Limit: limits.MaxInstrumentNotional, Value: instrumentNotional,
+		})
+	}
+	totalExposure := exposure.TotalExposure.Add(notional)
+	if limits.MaxTotalExposure.IsPositive() && totalExposure.GreaterThan(limits.MaxTotalExposure) {
+		violations = append(violations, model.RiskViolation{
+			Rule: model.RiskRuleMaxTotalExposure, Subject: profile, Limit: limits.MaxTotalExposure, Value: totalExposure,
+		})
+	}
+	if limits.MaxDailyLoss.IsPositive() && exposure.DailyLoss.GreaterThanOrEqual(limits.MaxDailyLoss) {
+		violations = append(violations, model.RiskViolation{
+			Rule: model.RiskRuleMaxDailyLoss, Subject: profile, Limit: limits.MaxDailyLoss, Value: exposure.DailyLoss,
+		})
+	}
+	return violations
+}
+
+// validateRiskLimits function checks that risk limits are not negative
+func validateRiskLimits(limits *model.RiskLimits) error {
+	validationErr := &model.ValidationError{}
+	if limits.MaxOpenPositions < 0 {
+		validationErr.Violations = append(validationErr.Violations, model.FieldViolation{
+			Field: "maxOpenPositions", Description: fmt.Sprintf("max open positions %v can't be negative", limits.MaxOpenPositions),
+		})
+	}
+	for _, limit := range []struct {
+		field string
+		value decimal.Decimal
+	}{
+		{"maxInstrumentNotional", limits.MaxInstrumentNotional},
+		{"maxTotalExposure", limits.MaxTotalExposure},
+		{"maxDailyLoss", limits.MaxDailyLoss},
+		{"maxOrderSize", limits.MaxOrderSize},
+	} {
+		if limit.value.IsNegative() {
+			validationErr.Violations = append(validationErr.Violations, model.FieldViolation{
+				Field: limit.field, Description: fmt.Sprintf("risk limit %v can't be negative", limit.value),
+			})
+		}
+	}
+	if len(validationErr.Violations) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// dayStart function returns the beginning of the day of given time in given location
+func dayStart(now time.Time, location *time.Location) time.Time {
+	local := now.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
@@ -0,0 +1,151 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func TestEvaluateRiskLimits(t *testing.T) {
+	limits := &model.RiskLimits{
+		ProfileID:             uuid.New(),
+		MaxOpenPositions:      3,
+		MaxInstrumentNotional: dec("5000"),
+		MaxTotalExposure:      dec("10000"),
+		MaxDailyLoss:          dec("500"),
+		MaxOrderSize:          dec("2000"),
+	}
+	exposure := &model.RiskExposure{
+		OpenPositions:      2,
+		InstrumentNotional: dec("3000"),
+		TotalExposure:      dec("7000"),
+		DailyLoss:          dec("100"),
+	}
+	tests := []struct {
+		name        string
+		limits      *model.RiskLimits
+		exposure    *model.RiskExposure
+		notional    string
+		newPosition bool
+		rules       []model.RiskRule
+	}{
+		{name: "within limits", limits: limits, exposure: exposure, notional: "1000", newPosition: true},
+		{name: "exactly at limits", limits: limits, exposure: exposure, notional: "2000", newPosition: true},
+		{name: "order size", limits: limits, exposure: &model.RiskExposure{}, notional: "2000.01", rules: []model.RiskRule{model.RiskRuleMaxOrderSize}},
+		{
+			name: "open positions", limits: limits, exposure: &model.RiskExposure{OpenPositions: 3}, notional: "100", newPosition: true,
+			rules: []model.RiskRule{model.RiskRuleMaxOpenPositions},
+		},
+		{name: "increase does not open a position", limits: limits, exposure: &model.RiskExposure{OpenPositions: 3}, notional: "100"},
+		{
+			name: "instrument notional", limits: limits, exposure: &model.RiskExposure{InstrumentNotional: dec("4500")}, notional: "600",
+			rules: []model.RiskRule{model.RiskRuleMaxInstrumentNotional},
+		},
+		{
+			name: "total exposure", limits: limits, exposure: &model.RiskExposure{TotalExposure: dec("9500")}, notional: "600",
+			rules: []model.RiskRule{model.RiskRuleMaxTotalExposure},
+		},
+		{
+			name: "daily loss is reached", limits: limits, exposure: &model.RiskExposure{DailyLoss: dec("500")}, notional: "100",
+			rules: []model.RiskRule{model.RiskRuleMaxDailyLoss},
+		},
+		{
+			name: "all limits", limits: limits, notional: "2500", newPosition: true,
+			exposure: &model.RiskExposure{OpenPositions: 3, InstrumentNotional: dec("4000"), TotalExposure: dec("9000"), DailyLoss: dec("600")},
+			rules: []model.RiskRule{
+				model.RiskRuleMaxOrderSize, model.RiskRuleMaxOpenPositions, model.RiskRuleMaxInstrumentNotional,
+				model.RiskRuleMaxTotalExposure, model.RiskRuleMaxDailyLoss,
+			},
+		},
+		{
+			name: "limits are not set", limits: &model.RiskLimits{}, notional: "1000000", newPosition: true,
+			exposure: &model.RiskExposure{OpenPositions: 100, InstrumentNotional: dec("1000000"), TotalExposure: dec("1000000"), DailyLoss: dec("1000000")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := evaluateRiskLimits(tt.limits, tt.exposure, "AAPL", dec(tt.notional), tt.newPosition)
+			if len(violations) != len(tt.rules) {
+				t.Fatalf("violations are %v, want rules %v", violations, tt.rules)
+			}
+			for i, violation := range violations {
+				if violation.Rule != tt.rules[i] {
+					t.Errorf("violation %d is %v, want %v", i, violation.Rule, tt.rules[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRiskLimits(t *testing.T) {
+	err := validateRiskLimits(&model.RiskLimits{MaxOpenPositions: 1, MaxOrderSize: dec("100")})
+	if err != nil {
+		t.Errorf("validateRiskLimits: %v", err)
+	}
+	err = validateRiskLimits(&model.RiskLimits{MaxOpenPositions: -1, MaxTotalExposure: dec("-1"), MaxDailyLoss: dec("-1")})
+	var validationErr *model.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("validateRiskLimits returned %v, want validation error", err)
+	}
+	if len(validationErr.Violations) != 3 {
+		t.Errorf("violations are %v, want 3 violations", validationErr.Violations)
+	}
+}
+
+func TestProfileLocksSerializeTradesOfProfile(t *testing.T) {
+	locks := newProfileLocks()
+	profileID := uuid.New()
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		holders int
+		overlap bool
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock(profileID)
+			defer unlock()
+			mu.Lock()
+			holders++
+			overlap = overlap || holders > 1
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			holders--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if overlap {
+		t.Error("trades of one profile held the lock at the same time")
+	}
+	if len(locks.locks) != 0 {
+		t.Errorf("%d locks are left after all trades, want 0", len(locks.locks))
+	}
+}
+
+func TestProfileLocksDoNotBlockOtherProfiles(t *testing.T) {
+	locks := newProfileLocks()
+	unlock := locks.lock(uuid.New())
+	defer unlock()
+
+	locked := make(chan struct{})
+	go func() {
+		unlockOther := locks.lock(uuid.New())
+		unlockOther()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("trade of another profile waited for the lock")
+	}
+}
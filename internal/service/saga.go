@@ -0,0 +1,438 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// sagaStaleAfter is a time after which pending saga is considered abandoned and can be resumed
+const sagaStaleAfter = time.Minute
+
+// openPositionSaga method reserves money on the balance, creates the position and then commits the reservation
+// Reservation is released if position can't be created, saga ID is used as idempotency key of balance operations
+// Reserved money is margin of the position plus commission of opening
+// Step position_created is stored together with the position, so on resume it is known if the reservation must be committed
+// Steps: started -> balance_reserved -> position_created -> balance_debited -> completed
+func (s *TradingService) openPositionSaga(ctx context.Context, position *model.Position) error {
+	saga := newSaga(model.SagaOpenPosition, position)
+	saga.Amount = position.Total.Add(position.OpenFee)
+	saga.SharePrice = position.SharePrice
+	err := s.rps.CreateSaga(ctx, saga)
+	if err != nil {
+		return fmt.Errorf("CreateSaga: %w", err)
+	}
+
+	err = s.balanceRps.Reserve(ctx, saga.ID, position.ProfileID, saga.Amount)
+	if err != nil {
+		s.finishSaga(ctx, saga, model.SagaStatusCompensated, err)
+		return fmt.Errorf("Reserve: %w", err)
+	}
+	s.advanceSaga(ctx, saga, model.SagaStepBalanceReserved)
+
+	events, err := newOpenedEvents(position)
+	if err != nil {
+		s.compensateOpenPosition(ctx, saga, err)
+		return fmt.Errorf("newOpenedEvents: %w", err)
+	}
+	saga.Step = model.SagaStepPositionCreated
+	saga.UpdatedAt = time.Now()
+	err = s.rps.CreatePosition(ctx, saga, events...)
+	if err != nil {
+		saga.Step = model.SagaStepBalanceReserved
+		s.compensateOpenPosition(ctx, saga, err)
+		return fmt.Errorf("CreatePosition: %w", err)
+	}
+	// position is watched as soon as it is persisted, so it can be closed and its stop loss and take profit are checked
+	// even if commit of the reservation fails and is retried when the saga is resumed
+	err = s.addPositionToMap(position.ProfileID, position)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"PositionID": position.ID}).Errorf("addPositionToMap: %v", err)
+	}
+
+	err = s.completeOpenPosition(ctx, saga)
+	if err != nil {
+		// position is persisted and reserved money will be debited when the saga is resumed
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "PositionID": position.ID}).Errorf("completeOpenPosition: %v", err)
+	}
+	return nil
+}
+
+// completeOpenPosition method commits reservation of the created position
+func (s *TradingService) completeOpenPosition(ctx context.Context, saga *model.Saga) error {
+	if saga.Step != model.SagaStepBalanceDebited {
+		err := s.balanceRps.Commit(ctx, saga.ID)
+		if err != nil {
+			saga.Error = err.Error()
+			s.advanceSaga(ctx, saga, saga.Step)
+			return fmt.Errorf("Commit: %w", err)
+		}
+		s.advanceSaga(ctx, saga, model.SagaStepBalanceDebited)
+	}
+	s.finishSaga(ctx, saga, model.SagaStatusCompleted, nil)
+	return nil
+}
+
+// compensateOpenPosition method releases reserved money of opened or increased position
+func (s *TradingService) compensateOpenPosition(ctx context.Context, saga *model.Saga, cause error) {
+	err := s.balanceRps.Release(ctx, saga.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "ProfileID": saga.Position.ProfileID}).Errorf("Release: %v", err)
+		s.finishSaga(ctx, saga, model.SagaStatusFailed, fmt.Errorf("%v, compensation failed: %w", cause, err))
+		return
+	}
+	s.finishSaga(ctx, saga, model.SagaStatusCompensated, cause)
+}
+
+// closePositionSaga method moves the position to history and credits its settlement to the balance
+// Settlement is net of commission of closing
+// Position is moved to closing status first, so only one request can close it, concurrent ones fail with model.ErrPositionConflict
+// Closing of position in database is the point of no return: after it the credit is retried until it succeeds
+// and the position is reported closed, so a retried request gets the stored result instead of model.ErrPositionNotOpen
+// Step position_closed is stored together with the closed position, so only the saga which closed the position credits its settlement
+// Steps: started -> position_closing -> position_closed -> balance_credited -> completed
+func (s *TradingService) closePositionSaga(ctx context.Context, position *model.Position, sharePrice, settlement, PnL decimal.Decimal, reason model.CloseReason) error {
+	saga := newSaga(model.SagaClosePosition, position)
+	saga.Amount = settlement
+	saga.SharePrice = sharePrice
+	saga.PnL = PnL
+	saga.Reason = reason
+	events, err := newClosedEvents(position, sharePrice, PnL, reason)
+	if err != nil {
+		return fmt.Errorf("newClosedEvents: %w", err)
+	}
+	err = s.rps.CreateSaga(ctx, saga)
+	if err != nil {
+		return fmt.Errorf("CreateSaga: %w", err)
+	}
+
+	saga.Step = model.SagaStepPositionClosing
+	saga.UpdatedAt = time.Now()
+	err = s.rps.StartClosingPosition(ctx, saga)
+	if err != nil {
+		saga.Step = model.SagaStepStarted
+		s.finishSaga(ctx, saga, model.SagaStatusCompensated, err)
+		return fmt.Errorf("StartClosingPosition: %w", err)
+	}
+	position.Version++
+
+	err = s.deletePositionFromMap(position.ProfileID, position.ID)
+	if err != nil {
+		s.compensateClosePosition(ctx, saga, err)
+		return fmt.Errorf("deletePositionFromMap: %w", err)
+	}
+	saga.Step = model.SagaStepPositionClosed
+	saga.UpdatedAt = time.Now()
+	err = s.rps.ClosePosition(ctx, saga, events...)
+	if err != nil {
+		saga.Step = model.SagaStepPositionClosing
+		mapErr := s.addPositionToMap(position.ProfileID, position)
+		if mapErr != nil {
+			logrus.WithFields(logrus.Fields{"PositionID": position.ID}).Errorf("addPositionToMap: %v", mapErr)
+		}
+		s.compensateClosePosition(ctx, saga, err)
+		return fmt.Errorf("ClosePosition: %w", err)
+	}
+	position.Version++
+
+	err = s.creditClosedPosition(ctx, saga)
+	if err != nil {
+		// position is closed and settlement will be credited when the saga is resumed
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "PositionID": position.ID}).Errorf("creditClosedPosition: %v", err)
+	}
+	return nil
+}
+
+// reducePositionSaga method stores remaining part of partially closed position with its closed part and credits settlement of the closed part
+// Saga position is the closed part, so saga is resumed the same way as closing of the whole position
+// Step position_closed is stored together with both parts of the position
+// Steps: started -> position_closed -> balance_credited -> completed
+func (s *TradingService) reducePositionSaga(ctx context.Context, position, remaining, closedPart *model.Position, sharePrice, settlement, PnL decimal.Decimal) error {
+	saga := newSaga(model.SagaClosePosition, closedPart)
+	saga.Amount = settlement
+	saga.SharePrice = sharePrice
+	saga.PnL = PnL
+	saga.Reason = closedPart.CloseReason
+	events, err := newClosedEvents(closedPart, sharePrice, PnL, closedPart.CloseReason)
+	if err != nil {
+		return fmt.Errorf("newClosedEvents: %w", err)
+	}
+	err = s.rps.CreateSaga(ctx, saga)
+	if err != nil {
+		return fmt.Errorf("CreateSaga: %w", err)
+	}
+
+	err = s.updatePositionInMap(remaining)
+	if err != nil {
+		s.finishSaga(ctx, saga, model.SagaStatusCompensated, err)
+		return fmt.Errorf("updatePositionInMap: %w", err)
+	}
+	saga.Step = model.SagaStepPositionClosed
+	saga.UpdatedAt = time.Now()
+	err = s.rps.ReducePosition(ctx, saga, remaining, events...)
+	if err != nil {
+		saga.Step = model.SagaStepStarted
+		mapErr := s.updatePositionInMap(position)
+		if mapErr != nil {
+			logrus.WithFields(logrus.Fields{"PositionID": position.ID}).Errorf("updatePositionInMap: %v", mapErr)
+		}
+		s.finishSaga(ctx, saga, model.SagaStatusCompensated, err)
+		return fmt.Errorf("ReducePosition: %w", err)
+	}
+	remaining.Version++
+
+	err = s.creditClosedPosition(ctx, saga)
+	if err != nil {
+		// part of position is closed and its settlement will be credited when the saga is resumed
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "PositionID": closedPart.ID}).Errorf("creditClosedPosition: %v", err)
+	}
+	return nil
+}
+
+// increasePositionSaga method reserves money on the balance, stores increased position and then commits the reservation
+// Step position_increased is stored together with the position, so on resume it is known if the reservation must be committed
+// Amount is added margin plus commission of buying the added shares
+// Steps: started -> balance_reserved -> position_increased -> balance_debited -> completed
+func (s *TradingService) increasePositionSaga(ctx context.Context, position, increased *model.Position, amount decimal.Decimal) error {
+	saga := newSaga(model.SagaIncreasePosition, increased)
+	saga.Amount = amount
+	saga.SharePrice = increased.SharePrice
+	err := s.rps.CreateSaga(ctx, saga)
+	if err != nil {
+		return fmt.Errorf("CreateSaga: %w", err)
+	}
+
+	err = s.balanceRps.Reserve(ctx, saga.ID, position.ProfileID, amount)
+	if err != nil {
+		s.finishSaga(ctx, saga, model.SagaStatusCompensated, err)
+		return fmt.Errorf("Reserve: %w", err)
+	}
+	s.advanceSaga(ctx, saga, model.SagaStepBalanceReserved)
+
+	event, err := newOutboxEvent(model.EventPositionIncreased, increased, increased)
+	if err != nil {
+		s.compensateOpenPosition(ctx, saga, err)
+		return fmt.Errorf("newOutboxEvent: %w", err)
+	}
+	saga.Step = model.SagaStepPositionIncreased
+	saga.UpdatedAt = time.Now()
+	err = s.rps.IncreasePosition(ctx, saga, position, event)
+	if err != nil {
+		saga.Step = model.SagaStepBalanceReserved
+		s.compensateOpenPosition(ctx, saga, err)
+		return fmt.Errorf("IncreasePosition: %w", err)
+	}
+	increased.Version++
+	err = s.updatePositionInMap(increased)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"PositionID": increased.ID}).Errorf("updatePositionInMap: %v", err)
+	}
+
+	err = s.commitIncreasedPosition(ctx, saga)
+	if err != nil {
+		// position is increased and reserved money will be debited when the saga is resumed
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "PositionID": increased.ID}).Errorf("commitIncreasedPosition: %v", err)
+	}
+	return nil
+}
+
+// commitIncreasedPosition method commits reservation of the increased position
+func (s *TradingService) commitIncreasedPosition(ctx context.Context, saga *model.Saga) error {
+	err := s.balanceRps.Commit(ctx, saga.ID)
+	if err != nil {
+		saga.Error = err.Error()
+		s.advanceSaga(ctx, saga, saga.Step)
+		return fmt.Errorf("Commit: %w", err)
+	}
+	s.advanceSaga(ctx, saga, model.SagaStepBalanceDebited)
+	s.finishSaga(ctx, saga, model.SagaStatusCompleted, nil)
+	return nil
+}
+
+// compensateClosePosition method opens again the position which was moved to closing status by the saga
+func (s *TradingService) compensateClosePosition(ctx context.Context, saga *model.Saga, cause error) {
+	err := s.rps.ReopenPosition(ctx, saga.Position.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "PositionID": saga.Position.ID}).Errorf("ReopenPosition: %v", err)
+		s.finishSaga(ctx, saga, model.SagaStatusFailed, fmt.Errorf("%v, compensation failed: %w", cause, err))
+		return
+	}
+	s.finishSaga(ctx, saga, model.SagaStatusCompensated, cause)
+}
+
+// creditClosedPosition method credits settlement of the closed position, saga stays pending on failure to be resumed later
+func (s *TradingService) creditClosedPosition(ctx context.Context, saga *model.Saga) error {
+	err := s.balanceRps.Credit(ctx, saga.ID, saga.Position.ProfileID, saga.Amount)
+	if err != nil {
+		saga.Error = err.Error()
+		s.advanceSaga(ctx, saga, saga.Step)
+		return fmt.Errorf("Credit: %w", err)
+	}
+	s.advanceSaga(ctx, saga, model.SagaStepBalanceCredited)
+	s.finishSaga(ctx, saga, model.SagaStatusCompleted, nil)
+	return nil
+}
+
+// ResumeSagas method finishes or compensates pending sagas which were not updated during given time
+// On startup all pending sagas are abandoned by a crash, so it is called with zero staleAfter
+func (s *TradingService) ResumeSagas(ctx context.Context, staleAfter time.Duration) error {
+	sagas, err := s.rps.GetPendingSagas(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		return fmt.Errorf("GetPendingSagas: %w", err)
+	}
+	for _, saga := range sagas {
+		err = s.resumeSaga(ctx, saga)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "Type": saga.Type, "Step": saga.Step}).Errorf("resumeSaga: %v", err)
+		}
+	}
+	return nil
+}
+
+// ResumeSagasPeriodically method resumes abandoned sagas with given interval until context is done
+func (s *TradingService) ResumeSagasPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.ResumeSagas(ctx, sagaStaleAfter)
+			if err != nil {
+				logrus.Errorf("ResumeSagas: %v", err)
+			}
+		}
+	}
+}
+
+// resumeSaga method continues the saga from its last persisted step
+// Steps changing positions are stored together with the positions, so money is committed or credited only by the saga
+// whose step tells that it changed the position, sagas abandoned before that are compensated
+func (s *TradingService) resumeSaga(ctx context.Context, saga *model.Saga) error {
+	switch saga.Type {
+	case model.SagaOpenPosition:
+		switch saga.Step {
+		case model.SagaStepStarted:
+			// reservation may have been made before the crash, releasing of unknown key does nothing
+			s.compensateOpenPosition(ctx, saga, errors.New("abandoned before balance reservation"))
+			return nil
+		case model.SagaStepBalanceReserved:
+			s.compensateOpenPosition(ctx, saga, errors.New("abandoned before position creation"))
+			return nil
+		}
+		err := s.watchOpenPosition(ctx, saga.Position)
+		if err != nil {
+			return fmt.Errorf("watchOpenPosition: %w", err)
+		}
+		err = s.completeOpenPosition(ctx, saga)
+		if err != nil {
+			return fmt.Errorf("completeOpenPosition: %w", err)
+		}
+	case model.SagaIncreasePosition:
+		switch saga.Step {
+		case model.SagaStepStarted, model.SagaStepBalanceReserved:
+			s.compensateOpenPosition(ctx, saga, errors.New("abandoned before position increase"))
+		default:
+			err := s.commitIncreasedPosition(ctx, saga)
+			if err != nil {
+				return fmt.Errorf("commitIncreasedPosition: %w", err)
+			}
+		}
+	case model.SagaClosePosition:
+		switch saga.Step {
+		case model.SagaStepStarted:
+			// position or its part was not closed by the saga, even if it was closed by another one since then
+			s.finishSaga(ctx, saga, model.SagaStatusCompensated, errors.New("abandoned before position closing"))
+			return nil
+		case model.SagaStepPositionClosing:
+			// position was moved to closing status by the saga, it is opened and watched again
+			s.compensateClosePosition(ctx, saga, errors.New("abandoned before position closing"))
+			err := s.watchOpenPosition(ctx, saga.Position)
+			if err != nil {
+				return fmt.Errorf("watchOpenPosition: %w", err)
+			}
+			return nil
+		}
+		err := s.creditClosedPosition(ctx, saga)
+		if err != nil {
+			return fmt.Errorf("creditClosedPosition: %w", err)
+		}
+	}
+	return nil
+}
+
+// watchOpenPosition method adds the position of resumed saga to position manager if it is open in database and not watched yet
+// Position may have been closed since the saga changed it, so closed position is not watched
+func (s *TradingService) watchOpenPosition(ctx context.Context, position *model.Position) error {
+	status, err := s.getPositionStatus(ctx, position.ID)
+	if err != nil {
+		return fmt.Errorf("getPositionStatus: %w", err)
+	}
+	if status != model.PositionStatusOpen || s.isPositionInManager(position.ProfileID, position.ID) {
+		return nil
+	}
+	err = s.addPositionToMap(position.ProfileID, position)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"PositionID": position.ID}).Errorf("addPositionToMap: %v", err)
+	}
+	return nil
+}
+
+// getPositionStatus method returns status of position in database or empty status if it is not present there
+func (s *TradingService) getPositionStatus(ctx context.Context, positionID uuid.UUID) (model.PositionStatus, error) {
+	position, err := s.rps.GetPositionByID(ctx, positionID)
+	if errors.Is(err, model.ErrPositionNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("GetPositionByID: %w", err)
+	}
+	return position.Status, nil
+}
+
+// newSaga function creates a new pending saga of given type
+func newSaga(sagaType model.SagaType, position *model.Position) *model.Saga {
+	now := time.Now()
+	return &model.Saga{
+		ID:        uuid.New(),
+		Type:      sagaType,
+		Step:      model.SagaStepStarted,
+		Status:    model.SagaStatusPending,
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// advanceSaga method persists a new step of the saga, failure is only logged as the step is already done
+func (s *TradingService) advanceSaga(ctx context.Context, saga *model.Saga, step model.SagaStep) {
+	saga.Step = step
+	saga.UpdatedAt = time.Now()
+	err := s.rps.UpdateSaga(ctx, saga)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "Step": step}).Errorf("UpdateSaga: %v", err)
+	}
+}
+
+// finishSaga method persists the final status of the saga
+func (s *TradingService) finishSaga(ctx context.Context, saga *model.Saga, status model.SagaStatus, cause error) {
+	saga.Status = status
+	saga.Error = ""
+	if cause != nil {
+		saga.Error = cause.Error()
+	}
+	saga.UpdatedAt = time.Now()
+	err := s.rps.UpdateSaga(ctx, saga)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"SagaID": saga.ID, "Status": status}).Errorf("UpdateSaga: %v", err)
+	}
+}
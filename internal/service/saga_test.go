@@ -0,0 +1,220 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// abandonedSaga struct is a state left by a saga which was interrupted after given step
+type abandonedSaga struct {
+	rps      *memoryRepository
+	balance  *memoryBalance
+	saga     *model.Saga
+	position *model.Position
+}
+
+// store method stores the position with given status and version
+func (a *abandonedSaga) store(position *model.Position, status model.PositionStatus, version int64) {
+	stored := *position
+	stored.Status = status
+	stored.Version = version
+	a.rps.positions[stored.ID] = &stored
+}
+
+// reserve method reserves amount of the saga on the balance
+func (a *abandonedSaga) reserve(t *testing.T) {
+	err := a.balance.Reserve(context.Background(), a.saga.ID, a.position.ProfileID, a.saga.Amount)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+}
+
+// commit method debits reserved amount of the saga from the balance
+func (a *abandonedSaga) commit(t *testing.T) {
+	a.reserve(t)
+	err := a.balance.Commit(context.Background(), a.saga.ID)
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+// credit method credits amount of the saga to the balance
+func (a *abandonedSaga) credit(t *testing.T) {
+	err := a.balance.Credit(context.Background(), a.saga.ID, a.position.ProfileID, a.saga.Amount)
+	if err != nil {
+		t.Fatalf("Credit: %v", err)
+	}
+}
+
+func TestResumeSagas(t *testing.T) {
+	tests := []struct {
+		name        string
+		sagaType    model.SagaType
+		step        model.SagaStep
+		partial     bool
+		prepare     func(t *testing.T, a *abandonedSaga)
+		wantStatus  model.SagaStatus
+		wantBalance string
+		wantWatched bool
+	}{
+		{
+			name: "open started before reservation", sagaType: model.SagaOpenPosition, step: model.SagaStepStarted,
+			prepare:    func(t *testing.T, a *abandonedSaga) {},
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "open started after reservation", sagaType: model.SagaOpenPosition, step: model.SagaStepStarted,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.reserve(t) },
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "open balance reserved", sagaType: model.SagaOpenPosition, step: model.SagaStepBalanceReserved,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.reserve(t) },
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "open position created", sagaType: model.SagaOpenPosition, step: model.SagaStepPositionCreated,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.reserve(t)
+				a.store(a.position, model.PositionStatusOpen, 0)
+			},
+			wantStatus: model.SagaStatusCompleted, wantBalance: "900", wantWatched: true,
+		},
+		{
+			name: "open position created and closed since then", sagaType: model.SagaOpenPosition, step: model.SagaStepPositionCreated,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.reserve(t)
+				a.store(a.position, model.PositionStatusClosed, 2)
+			},
+			wantStatus: model.SagaStatusCompleted, wantBalance: "900",
+		},
+		{
+			name: "open balance debited", sagaType: model.SagaOpenPosition, step: model.SagaStepBalanceDebited,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.commit(t)
+				a.store(a.position, model.PositionStatusOpen, 0)
+			},
+			wantStatus: model.SagaStatusCompleted, wantBalance: "900", wantWatched: true,
+		},
+		{
+			name: "increase started", sagaType: model.SagaIncreasePosition, step: model.SagaStepStarted,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.reserve(t)
+				a.store(a.position, model.PositionStatusOpen, 0)
+			},
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "increase balance reserved", sagaType: model.SagaIncreasePosition, step: model.SagaStepBalanceReserved,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.reserve(t)
+				a.store(a.position, model.PositionStatusOpen, 0)
+			},
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "increase position increased", sagaType: model.SagaIncreasePosition, step: model.SagaStepPositionIncreased,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.reserve(t)
+				a.store(a.position, model.PositionStatusOpen, 1)
+			},
+			wantStatus: model.SagaStatusCompleted, wantBalance: "900",
+		},
+		{
+			name: "close started", sagaType: model.SagaClosePosition, step: model.SagaStepStarted,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.store(a.position, model.PositionStatusOpen, 0) },
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "close started and position closed by another saga", sagaType: model.SagaClosePosition, step: model.SagaStepStarted,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.store(a.position, model.PositionStatusClosed, 2) },
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "close position closing", sagaType: model.SagaClosePosition, step: model.SagaStepPositionClosing,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.store(a.position, model.PositionStatusClosing, 1) },
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000", wantWatched: true,
+		},
+		{
+			name: "close position closed", sagaType: model.SagaClosePosition, step: model.SagaStepPositionClosed,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.store(a.position, model.PositionStatusClosed, 2) },
+			wantStatus: model.SagaStatusCompleted, wantBalance: "1100",
+		},
+		{
+			name: "close balance credited", sagaType: model.SagaClosePosition, step: model.SagaStepBalanceCredited,
+			prepare: func(t *testing.T, a *abandonedSaga) {
+				a.store(a.position, model.PositionStatusClosed, 2)
+				a.credit(t)
+			},
+			wantStatus: model.SagaStatusCompleted, wantBalance: "1100",
+		},
+		{
+			name: "reduce started", sagaType: model.SagaClosePosition, step: model.SagaStepStarted, partial: true,
+			prepare:    func(t *testing.T, a *abandonedSaga) {},
+			wantStatus: model.SagaStatusCompensated, wantBalance: "1000",
+		},
+		{
+			name: "reduce position closed", sagaType: model.SagaClosePosition, step: model.SagaStepPositionClosed, partial: true,
+			prepare:    func(t *testing.T, a *abandonedSaga) { a.store(a.position, model.PositionStatusClosed, 0) },
+			wantStatus: model.SagaStatusCompleted, wantBalance: "1100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			rps := newMemoryRepository()
+			balance := newMemoryBalance(profileID, dec("1000"))
+			s, _ := newMemoryService(rps, balance, "AAPL", dec("30"), 0)
+			position := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true,
+				SharePrice: dec("30"), Total: dec("90"), ShareAmount: dec("3"), Leverage: decimal.NewFromInt(1)}
+			saga := newSaga(tt.sagaType, position)
+			saga.Step = tt.step
+			saga.Amount = dec("100")
+			saga.UpdatedAt = time.Now().Add(-time.Minute)
+			if tt.partial {
+				// saga of partial closing holds the closed part, remaining part stays open
+				remaining := *position
+				rps.positions[remaining.ID] = &remaining
+				closedPart := *position
+				closedPart.ID = uuid.New()
+				closedPart.ParentID = &position.ID
+				saga.Position = &closedPart
+			}
+			err := rps.CreateSaga(context.Background(), saga)
+			if err != nil {
+				t.Fatalf("CreateSaga: %v", err)
+			}
+			tt.prepare(t, &abandonedSaga{rps: rps, balance: balance, saga: saga, position: saga.Position})
+
+			// second resume must not change anything as the saga is not pending anymore
+			for i := 0; i < 2; i++ {
+				err = s.ResumeSagas(context.Background(), 0)
+				if err != nil {
+					t.Fatalf("ResumeSagas: %v", err)
+				}
+			}
+
+			if got := rps.saga(saga.ID); got.Status != tt.wantStatus {
+				t.Errorf("saga status is %v (%s), want %v", got.Status, got.Error, tt.wantStatus)
+			}
+			if got := balance.balance(profileID); !got.Equal(dec(tt.wantBalance)) {
+				t.Errorf("balance is %v, want %v", got, tt.wantBalance)
+			}
+			if got := s.isPositionInManager(profileID, saga.Position.ID); got != tt.wantWatched {
+				t.Errorf("position is watched: %v, want %v", got, tt.wantWatched)
+			}
+			if tt.step == model.SagaStepPositionClosing {
+				if got := rps.positions[position.ID].Status; got != model.PositionStatusOpen {
+					t.Errorf("position status after compensation is %v, want %v", got, model.PositionStatusOpen)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eugenshima/trading-service/internal/model"
 	"github.com/sirupsen/logrus"
@@ -12,41 +14,101 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// limits of positions page size
+const (
+	defaultPositionsLimit = 50
+	maxPositionsLimit     = 500
+)
+
 // TradingService struct represents a trading-service-service
 type TradingService struct {
 	rps             TradingRepository
 	priceServiceRps PriceServiceRepository
 	balanceRps      BalanceRepository
 	positionManager *model.PositionManager
+	marginRules     *model.MarginRules
+	schedule        *model.TradingSchedule
+	rounding        *model.RoundingRules
+	instruments     *model.InstrumentRegistry
+	commissions     *model.CommissionRules
+	riskLimits      *model.RiskLimits
+	idempotencyTTL  time.Duration
+	tradeLocks      *profileLocks
 }
 
 // NewTradingService creates a new TradingService
-func NewTradingService(rps TradingRepository, priceServiceRps PriceServiceRepository, balanceRps BalanceRepository, positionManager *model.PositionManager) *TradingService {
+func NewTradingService(rps TradingRepository, priceServiceRps PriceServiceRepository, balanceRps BalanceRepository, positionManager *model.PositionManager, marginRules *model.MarginRules, schedule *model.TradingSchedule, rounding *model.RoundingRules, instruments *model.InstrumentRegistry, commissions *model.CommissionRules, riskLimits *model.RiskLimits, idempotencyTTL time.Duration) *TradingService {
 	return &TradingService{
 		rps:             rps,
 		priceServiceRps: priceServiceRps,
 		balanceRps:      balanceRps,
 		positionManager: positionManager,
+		marginRules:     marginRules,
+		schedule:        schedule,
+		rounding:        rounding,
+		instruments:     instruments,
+		commissions:     commissions,
+		riskLimits:      riskLimits,
+		idempotencyTTL:  idempotencyTTL,
+		tradeLocks:      newProfileLocks(),
 	}
 }
 
 // TradingRepository interface represents a trading-service-repository methods
 type TradingRepository interface {
-	CreatePosition(context.Context, *model.Position) error
-	DeletePosition(context.Context, uuid.UUID) error
+	CreatePosition(context.Context, *model.Saga, ...*model.OutboxEvent) error
+	StartClosingPosition(context.Context, *model.Saga) error
+	ReopenPosition(context.Context, uuid.UUID) error
+	ClosePosition(context.Context, *model.Saga, ...*model.OutboxEvent) error
+	ReducePosition(context.Context, *model.Saga, *model.Position, ...*model.OutboxEvent) error
+	IncreasePosition(context.Context, *model.Saga, *model.Position, ...*model.OutboxEvent) error
+	UpdateStopLoss(context.Context, uuid.UUID, decimal.Decimal) error
 	GetPositionByID(context.Context, uuid.UUID) (*model.Position, error)
 	GetAllIDsPositions(context.Context, uuid.UUID) ([]*model.Position, error)
+	ListPositions(context.Context, *model.PositionFilter) ([]*model.Position, error)
+	ListClosedTrades(context.Context, *model.ClosedTradeFilter) ([]*model.Position, error)
+	GetAllPositions(context.Context) ([]*model.Position, error)
+	CreateSaga(context.Context, *model.Saga) error
+	UpdateSaga(context.Context, *model.Saga) error
+	GetPendingSagas(context.Context, time.Time) ([]*model.Saga, error)
+	CreateOrders(context.Context, ...*model.Order) error
+	AmendOrder(context.Context, *model.Order) error
+	GetExitOrders(context.Context, uuid.UUID) ([]*model.Order, error)
+	GetOrderByID(context.Context, uuid.UUID) (*model.Order, error)
+	UpdateOrderStatus(context.Context, *model.Order, model.OrderStatus, ...*model.OutboxEvent) error
+	ListOrders(context.Context, *model.OrderFilter) ([]*model.Order, error)
+	GetPendingOrders(context.Context) ([]*model.Order, error)
+	CreateOutboxEvents(context.Context, ...*model.OutboxEvent) error
+	CreateInstrument(context.Context, *model.Instrument) error
+	UpdateInstrument(context.Context, *model.Instrument) error
+	GetInstruments(context.Context) ([]*model.Instrument, error)
+	GetMonthlyVolume(context.Context, uuid.UUID, time.Time) (decimal.Decimal, error)
+	GetRiskLimits(context.Context, uuid.UUID) (*model.RiskLimits, error)
+	SetRiskLimits(context.Context, *model.RiskLimits) error
+	GetRealizedPnL(context.Context, uuid.UUID, time.Time) (decimal.Decimal, error)
+	ClaimIdempotencyKey(context.Context, *model.IdempotencyRecord) (bool, error)
+	GetIdempotencyRecord(context.Context, uuid.UUID, string, model.IdempotencyOperation) (*model.IdempotencyRecord, error)
+	ReclaimIdempotencyKey(context.Context, *model.IdempotencyRecord) (bool, error)
+	CompleteIdempotencyKey(context.Context, *model.IdempotencyRecord) error
+	DeleteIdempotencyKey(context.Context, uuid.UUID, string, model.IdempotencyOperation) error
+	DeleteExpiredIdempotencyKeys(context.Context, time.Time) (int64, error)
 }
 
 // PriceServiceRepository interface represents a price-service-repository methods
 type PriceServiceRepository interface {
 	AddSubscriber(context.Context, []string) (*model.Share, error)
+	SubscribeShares(context.Context, []string) (<-chan []*model.Share, error)
+	AddShares(...string)
+	RemoveShares(...string)
 }
 
 // BalanceRepository interface represents balance-repository methods
 type BalanceRepository interface {
 	GetBalance(context.Context, uuid.UUID) (*model.Balance, error)
-	UpdateBalance(context.Context, *model.Balance) error
+	Reserve(ctx context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error
+	Commit(ctx context.Context, key uuid.UUID) error
+	Release(ctx context.Context, key uuid.UUID) error
+	Credit(ctx context.Context, key, profileID uuid.UUID, amount decimal.Decimal) error
 }
 
 // addPositionToMap method adds a position to position manager
@@ -54,16 +116,28 @@ func (s *TradingService) addPositionToMap(ProfileID uuid.UUID, position *model.P
 	s.positionManager.Mu.Lock()
 	defer s.positionManager.Mu.Unlock()
 
-	s.positionManager.OpenedPositions[ProfileID] = make(map[uuid.UUID]*model.OpenedPosition)
+	if _, ok := s.positionManager.OpenedPositions[ProfileID]; !ok {
+		s.positionManager.OpenedPositions[ProfileID] = make(map[uuid.UUID]*model.OpenedPosition)
+	}
 	openedPosition := &model.OpenedPosition{
-		PositionID:      position.ID,
-		ShareOpenPrice:  position.SharePrice,
-		ShareClosePrice: position.StopLoss,
-		ShareAmount:     position.ShareAmount,
-		IsOpened:        true,
+		PositionID:           position.ID,
+		ProfileID:            ProfileID,
+		ShareName:            position.ShareName,
+		IsLong:               position.IsLong,
+		ShareOpenPrice:       position.SharePrice,
+		ShareClosePrice:      position.StopLoss,
+		TakeProfit:           position.TakeProfit,
+		ShareAmount:          position.ShareAmount,
+		IsOpened:             true,
+		TrailingStopType:     position.TrailingStopType,
+		TrailingStopDistance: position.TrailingStopDistance,
+		Margin:               position.Total,
+		Leverage:             position.Leverage,
+		ExpiresAt:            position.ExpiresAt,
 	}
 	if _, ok := s.positionManager.OpenedPositions[ProfileID][position.ID]; !ok {
 		s.positionManager.OpenedPositions[ProfileID][position.ID] = openedPosition
+		s.priceServiceRps.AddShares(position.ShareName)
 		return nil
 	}
 	return fmt.Errorf("error opening position on ID: %v", ProfileID)
@@ -73,239 +147,769 @@ func (s *TradingService) addPositionToMap(ProfileID uuid.UUID, position *model.P
 func (s *TradingService) deletePositionFromMap(ProfileID, positionID uuid.UUID) error {
 	s.positionManager.Mu.Lock()
 	defer s.positionManager.Mu.Unlock()
-	if _, ok := s.positionManager.OpenedPositions[ProfileID]; ok {
+	if openedPosition, ok := s.positionManager.OpenedPositions[ProfileID][positionID]; ok {
+		s.priceServiceRps.RemoveShares(openedPosition.ShareName)
 		delete(s.positionManager.Closed, positionID)
 		delete(s.positionManager.OpenedPositions[ProfileID], positionID)
+		if len(s.positionManager.OpenedPositions[ProfileID]) == 0 {
+			delete(s.positionManager.OpenedPositions, ProfileID)
+		}
 		return nil
 	}
 	return fmt.Errorf("error closing position on ID: %v", positionID)
 }
 
+// updatePositionInMap method sets open price, amount of shares and margin of a position in position manager
+func (s *TradingService) updatePositionInMap(position *model.Position) error {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	openedPosition, ok := s.positionManager.OpenedPositions[position.ProfileID][position.ID]
+	if !ok {
+		return fmt.Errorf("error updating position on ID: %v", position.ID)
+	}
+	openedPosition.ShareOpenPrice = position.SharePrice
+	openedPosition.ShareAmount = position.ShareAmount
+	openedPosition.Margin = position.Total
+	return nil
+}
+
+// markPositionClosing method marks a position as being closed, returns false if it is already closing
+func (s *TradingService) markPositionClosing(positionID uuid.UUID) bool {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	if s.positionManager.Closed[positionID] {
+		return false
+	}
+	s.positionManager.Closed[positionID] = true
+	return true
+}
+
+// unmarkPositionClosing method removes closing mark from a position if its closing failed
+func (s *TradingService) unmarkPositionClosing(positionID uuid.UUID) {
+	s.positionManager.Mu.Lock()
+	defer s.positionManager.Mu.Unlock()
+	delete(s.positionManager.Closed, positionID)
+}
+
 // OpenPosition creates a position for a given ID with checking all the necessary conditions
-func (s *TradingService) OpenPosition(ctx context.Context, position *model.Position) error {
-	balance, err := s.balanceRps.GetBalance(ctx, position.ProfileID)
+// Retried request with the same idempotency key gets the originally opened position instead of opening a new one
+func (s *TradingService) OpenPosition(ctx context.Context, position *model.Position, idempotencyKey string) error {
+	// ID of a new position is generated for every request, so it is not a part of the request
+	request := *position
+	request.ID = uuid.Nil
+	return s.runIdempotent(ctx, position.ProfileID, idempotencyKey, model.IdempotencyOpenPosition, &request, position, func(reclaimed bool) error {
+		if reclaimed {
+			// position has ID given to the crashed request, which may have created it before
+			created, err := s.rps.GetPositionByID(ctx, position.ID)
+			if err == nil {
+				*position = *created
+				return nil
+			}
+			if !errors.Is(err, model.ErrPositionNotFound) {
+				return fmt.Errorf("GetPositionByID: %w", err)
+			}
+		}
+		return s.openPosition(ctx, position)
+	})
+}
+
+// openPosition method creates a position for a given ID with checking all the necessary conditions
+// Total of the position becomes margin of the bought shares, which is less than requested if their amount is truncated
+// Risk limits of the profile are checked before money is reserved, rejection is returned as *model.RiskLimitError
+func (s *TradingService) openPosition(ctx context.Context, position *model.Position) error {
+	instrument, err := s.GetTradableInstrument(position.ShareName)
 	if err != nil {
-		return fmt.Errorf("GetBalance: %w", err)
+		return fmt.Errorf("GetTradableInstrument: %w", err)
 	}
-
-	updatedBalance, err := checkIfEnoughMoneyOnBalance(balance, position.Total)
+	sharePrice, err := s.getSharePrice(ctx, position.ShareName)
 	if err != nil {
-		return fmt.Errorf("not enough money on you balance: %w", err)
+		return fmt.Errorf("getSharePrice:%w", err)
 	}
 
-	selectedShares := []string{position.ShareName}
-	share, err := s.priceServiceRps.AddSubscriber(ctx, selectedShares)
+	if position.Leverage.IsZero() {
+		position.Leverage = decimal.NewFromInt(1)
+	}
+	err = validateLeverage(position.Leverage, s.marginRules)
 	if err != nil {
-		return fmt.Errorf("AddSubscriber:%w", err)
+		return fmt.Errorf("validateLeverage: %w", err)
 	}
-
-	shareAmount, err := calculateAmountOfShares(ctx, position.Total, share.SharePrice)
+	precision := instrument.Precision()
+	position.Total = s.rounding.Money(position.Total)
+	position.StopLoss = precision.Price(position.StopLoss)
+	position.TakeProfit = precision.Price(position.TakeProfit)
+	exposure := calculateExposure(position.Total, position.Leverage)
+	err = checkOrderValue(instrument, exposure)
+	if err != nil {
+		return fmt.Errorf("checkOrderValue: %w", err)
+	}
+	shareAmount, err := calculateAmountOfShares(ctx, exposure, sharePrice, precision)
 	if err != nil {
 		return fmt.Errorf("calculateAmountOfShares:%w", err)
 	}
+	err = checkMinLot(instrument, shareAmount)
+	if err != nil {
+		return fmt.Errorf("checkMinLot: %w", err)
+	}
+	notional := sharePrice.Mul(shareAmount)
+	// truncated amount of shares costs less than requested, so only margin of the bought shares is reserved and debited
+	position.Total = s.rounding.Money(notional.Div(position.Leverage))
+	// risk limits are checked against opened positions, so trades of the profile are serialized until the position is watched
+	unlock := s.tradeLocks.lock(position.ProfileID)
+	defer unlock()
+	err = s.checkRiskLimits(ctx, position.ProfileID, position.ShareName, notional, true)
+	if err != nil {
+		return fmt.Errorf("checkRiskLimits: %w", err)
+	}
+	openFee, err := s.calculateFee(ctx, position.ProfileID, position.ShareName, notional)
+	if err != nil {
+		return fmt.Errorf("calculateFee: %w", err)
+	}
 
 	position.ShareAmount = shareAmount
-	position.SharePrice = share.SharePrice
+	position.SharePrice = sharePrice
+	position.OpenFee = openFee
+	position.Status = model.PositionStatusOpen
+	position.OpenedAt = time.Now()
 
-	err = s.addPositionToMap(position.ProfileID, position)
+	if position.ExpiresAt != nil && !position.ExpiresAt.After(position.OpenedAt) {
+		return model.NewValidationError("expiresAt", "expiry time %v has already passed", position.ExpiresAt)
+	}
+	err = validateTrailingStop(position)
 	if err != nil {
-		return fmt.Errorf("addPositionToMap: %w", err)
+		return fmt.Errorf("validateTrailingStop: %w", err)
 	}
-
-	err = s.rps.CreatePosition(ctx, position)
+	if position.TrailingStopType != model.TrailingStopNone {
+		position.StopLoss = calculateTrailingStop(position.IsLong, position.TrailingStopType, position.TrailingStopDistance, position.SharePrice, precision)
+	}
+	err = validateStopLossAndTakeProfit(position)
 	if err != nil {
-		return fmt.Errorf("CreatePosition:%w", err)
+		return fmt.Errorf("validateStopLossAndTakeProfit: %w", err)
 	}
 
-	err = s.balanceRps.UpdateBalance(ctx, updatedBalance)
+	err = s.openPositionSaga(ctx, position)
 	if err != nil {
-		return fmt.Errorf("UpdateBalance:%w", err)
+		return fmt.Errorf("openPositionSaga:%w", err)
 	}
 
+	s.publishPositionUpdate(newOpenedUpdate(position))
 	return nil
 }
 
-// ClosePosition method closes the position of given ID
-func (s *TradingService) ClosePosition(ctx context.Context, PositionID uuid.UUID) (float64, error) {
+// ClosePosition method closes the whole position of given ID or only given part of it
+// Retried request with the same idempotency key gets PnL of the original closing instead of closing again
+// Keys are scoped by profile of the position, so different profiles can use the same key
+func (s *TradingService) ClosePosition(ctx context.Context, PositionID uuid.UUID, part model.ClosePart, idempotencyKey string) (*model.ProfitAndLoss, error) {
+	profileID := uuid.Nil
+	if idempotencyKey != "" {
+		position, err := s.rps.GetPositionByID(ctx, PositionID)
+		if err != nil {
+			return nil, fmt.Errorf("GetPositionByID: %w", err)
+		}
+		profileID = position.ProfileID
+	}
+	request := &closeRequest{PositionID: PositionID, Part: part}
+	profitAndLoss := &model.ProfitAndLoss{}
+	// retry which reclaims the key of a crashed request closes the position again, it fails with model.ErrPositionNotOpen if the position was closed
+	err := s.runIdempotent(ctx, profileID, idempotencyKey, model.IdempotencyClosePosition, request, profitAndLoss, func(bool) error {
+		closed, err := s.closePositionPart(ctx, PositionID, part)
+		if err != nil {
+			return err
+		}
+		*profitAndLoss = *closed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profitAndLoss, nil
+}
+
+// closeRequest struct represents a request of closing identified by idempotency key
+type closeRequest struct {
+	PositionID uuid.UUID       `json:"position_id"`
+	Part       model.ClosePart `json:"part"`
+}
+
+// closePositionPart method closes the whole position of given ID or only given part of it
+// Returned PnL is of the closed part before and after commissions
+// Closing of a position changed by another request fails with model.ErrPositionConflict, of a closed one with model.ErrPositionNotOpen
+func (s *TradingService) closePositionPart(ctx context.Context, PositionID uuid.UUID, part model.ClosePart) (*model.ProfitAndLoss, error) {
 	position, err := s.rps.GetPositionByID(ctx, PositionID)
 	if err != nil {
-		return 0, fmt.Errorf("GetPositionByID: %w", err)
+		return nil, fmt.Errorf("GetPositionByID: %w", err)
+	}
+	if position.Status != model.PositionStatusOpen {
+		return nil, fmt.Errorf("position %v is %s: %w", position.ID, position.Status, model.ErrPositionNotOpen)
+	}
+	closedShareAmount, err := calculateClosedShareAmount(position, part, s.precision(position.ShareName))
+	if err != nil {
+		return nil, fmt.Errorf("calculateClosedShareAmount: %w", err)
+	}
+	if !s.markPositionClosing(position.ID) {
+		return nil, fmt.Errorf("position %v is already closing: %w", position.ID, model.ErrPositionConflict)
+	}
+	sharePrice, err := s.getSharePrice(ctx, position.ShareName)
+	if err != nil {
+		s.unmarkPositionClosing(position.ID)
+		return nil, fmt.Errorf("getSharePrice:%w", err)
+	}
+	var profitAndLoss *model.ProfitAndLoss
+	if closedShareAmount.LessThan(position.ShareAmount) {
+		profitAndLoss, err = s.closePartOfPosition(ctx, position, closedShareAmount, sharePrice)
+		s.unmarkPositionClosing(position.ID)
+		if err != nil {
+			return nil, fmt.Errorf("closePartOfPosition:%w", err)
+		}
+		return profitAndLoss, nil
+	}
+	profitAndLoss, err = s.closePosition(ctx, position, sharePrice, model.CloseReasonManual)
+	if err != nil {
+		s.unmarkPositionClosing(position.ID)
+		return nil, fmt.Errorf("closePosition:%w", err)
+	}
+	return profitAndLoss, nil
+}
+
+// IncreasePosition method buys shares for given amount of money into the opened position of given ID with leverage of the position
+// Only margin of the bought shares is added to the position and debited, so money left after truncation of their amount stays on the balance
+// Open price of the position becomes volume-weighted average of its previous and current share price
+func (s *TradingService) IncreasePosition(ctx context.Context, PositionID uuid.UUID, total decimal.Decimal) (*model.Position, error) {
+	total = s.rounding.Money(total)
+	if !total.IsPositive() {
+		return nil, model.NewValidationError("total", "total must be positive")
+	}
+	position, err := s.rps.GetPositionByID(ctx, PositionID)
+	if err != nil {
+		return nil, fmt.Errorf("GetPositionByID: %w", err)
+	}
+	if position.Status != model.PositionStatusOpen {
+		return nil, fmt.Errorf("position %v is %s: %w", position.ID, position.Status, model.ErrPositionNotOpen)
+	}
+	instrument, err := s.GetTradableInstrument(position.ShareName)
+	if err != nil {
+		return nil, fmt.Errorf("GetTradableInstrument: %w", err)
+	}
+	// closing mark keeps triggers and other changes away from the position while it is increased
+	if !s.markPositionClosing(position.ID) {
+		return nil, fmt.Errorf("position %v is already changing: %w", position.ID, model.ErrPositionConflict)
+	}
+	defer s.unmarkPositionClosing(position.ID)
+	sharePrice, err := s.getSharePrice(ctx, position.ShareName)
+	if err != nil {
+		return nil, fmt.Errorf("getSharePrice:%w", err)
+	}
+	precision := instrument.Precision()
+	exposure := calculateExposure(total, position.Leverage)
+	err = checkOrderValue(instrument, exposure)
+	if err != nil {
+		return nil, fmt.Errorf("checkOrderValue: %w", err)
+	}
+	shareAmount, err := calculateAmountOfShares(ctx, exposure, sharePrice, precision)
+	if err != nil {
+		return nil, fmt.Errorf("calculateAmountOfShares:%w", err)
+	}
+	err = checkMinLot(instrument, shareAmount)
+	if err != nil {
+		return nil, fmt.Errorf("checkMinLot: %w", err)
 	}
-	for _, value := range s.positionManager.OpenedPositions[position.ProfileID] {
-		fmt.Println(value)
+	notional := sharePrice.Mul(shareAmount)
+	total = s.rounding.Money(notional.Div(positionLeverage(position.Leverage)))
+	unlock := s.tradeLocks.lock(position.ProfileID)
+	defer unlock()
+	err = s.checkRiskLimits(ctx, position.ProfileID, position.ShareName, notional, false)
+	if err != nil {
+		return nil, fmt.Errorf("checkRiskLimits: %w", err)
 	}
-	err = s.deletePositionFromMap(position.ProfileID, position.ID)
+	openFee, err := s.calculateFee(ctx, position.ProfileID, position.ShareName, notional)
 	if err != nil {
-		return 0, fmt.Errorf("deletePositionFromMap:%w", err)
+		return nil, fmt.Errorf("calculateFee: %w", err)
 	}
-	balance, err := s.balanceRps.GetBalance(ctx, position.ProfileID)
+	increased := increasePosition(position, total, shareAmount, sharePrice, precision)
+	increased.OpenFee = position.OpenFee.Add(openFee)
+	err = validateStopLossAndTakeProfit(increased)
 	if err != nil {
-		return 0, fmt.Errorf("GetBalance: %w", err)
+		return nil, fmt.Errorf("validateStopLossAndTakeProfit: %w", err)
 	}
-	share, err := s.priceServiceRps.AddSubscriber(ctx, []string{position.ShareName})
+	err = s.increasePositionSaga(ctx, position, increased, total.Add(openFee))
 	if err != nil {
-		return 0, fmt.Errorf("AddSubscriber:%w", err)
+		return nil, fmt.Errorf("increasePositionSaga:%w", err)
 	}
 
-	currentTotal, PnL, err := calculateProfitAndLoss(ctx, position, share.SharePrice, balance.Balance)
+	s.publishPositionUpdate(newIncreasedUpdate(increased))
+	return increased, nil
+}
+
+// closePartOfPosition method settles given amount of shares of the position by given share price
+// Closed part is moved to trade history, the remaining part keeps its open price, stop loss and take profit
+func (s *TradingService) closePartOfPosition(ctx context.Context, position *model.Position, shareAmount, sharePrice decimal.Decimal) (*model.ProfitAndLoss, error) {
+	closedPart, remaining := splitPosition(position, shareAmount, s.rounding)
+	settlement, PnL, err := calculateProfitAndLoss(ctx, closedPart, sharePrice, s.rounding)
 	if err != nil {
-		return 0, fmt.Errorf("calculateProfitAndLoss:%w", err)
+		return nil, fmt.Errorf("calculateProfitAndLoss:%w", err)
 	}
-	updatedBalance := &model.Balance{
-		BalanceID: balance.BalanceID,
-		ProfileID: position.ProfileID,
-		Balance:   currentTotal,
+	fee, err := s.calculateFee(ctx, closedPart.ProfileID, closedPart.ShareName, sharePrice.Mul(closedPart.ShareAmount))
+	if err != nil {
+		return nil, fmt.Errorf("calculateFee: %w", err)
 	}
-	err = s.balanceRps.UpdateBalance(ctx, updatedBalance)
+	closedAt := time.Now()
+	closedPart.Status = model.PositionStatusClosed
+	closedPart.ExitPrice = sharePrice
+	closedPart.RealizedPnL = settlement.Sub(closedPart.Total)
+	closedPart.CloseReason = model.CloseReasonManual
+	closedPart.ClosedAt = &closedAt
+	closedPart.CloseFee, settlement = chargeCloseFee(settlement, fee)
+	err = s.reducePositionSaga(ctx, position, remaining, closedPart, sharePrice, settlement, PnL)
 	if err != nil {
-		return 0, fmt.Errorf("UpdateBalance:%w", err)
+		return nil, fmt.Errorf("reducePositionSaga:%w", err)
 	}
-	err = s.rps.DeletePosition(ctx, PositionID)
+
+	s.publishPositionUpdate(newReducedUpdate(remaining, sharePrice, PnL))
+	return newProfitAndLoss(closedPart), nil
+}
+
+// closePosition method settles the position by given share price and moves it to trade history
+// Commission of closing is deducted from settlement credited to the balance, pending exit orders of the position are canceled
+func (s *TradingService) closePosition(ctx context.Context, position *model.Position, sharePrice decimal.Decimal, reason model.CloseReason) (*model.ProfitAndLoss, error) {
+	settlement, PnL, err := calculateProfitAndLoss(ctx, position, sharePrice, s.rounding)
+	if err != nil {
+		return nil, fmt.Errorf("calculateProfitAndLoss:%w", err)
+	}
+	fee, err := s.calculateFee(ctx, position.ProfileID, position.ShareName, sharePrice.Mul(position.ShareAmount))
+	if err != nil {
+		return nil, fmt.Errorf("calculateFee: %w", err)
+	}
+	closedAt := time.Now()
+	position.Status = model.PositionStatusClosed
+	position.ExitPrice = sharePrice
+	position.RealizedPnL = settlement.Sub(position.Total)
+	position.CloseReason = reason
+	position.ClosedAt = &closedAt
+	position.CloseFee, settlement = chargeCloseFee(settlement, fee)
+	err = s.closePositionSaga(ctx, position, sharePrice, settlement, PnL, reason)
 	if err != nil {
-		return 0, fmt.Errorf("DeletePosition:%w", err)
+		return nil, fmt.Errorf("closePositionSaga:%w", err)
 	}
+	s.cancelExitOrders(ctx, position.ID)
 
-	return PnL, nil
+	s.publishPositionUpdate(newClosedUpdate(position, sharePrice, PnL, reason))
+	return newProfitAndLoss(position), nil
 }
 
-// CheckForDestinationAmount function checks for given price of share in goven position
-func (s *TradingService) CheckForShareClosePrice(ctx context.Context) {
+// CheckForShareClosePrice method watches prices of all opened positions and closes them when stop loss or take profit is reached
+// or when margin of their profile drops below liquidation level
+func (s *TradingService) CheckForShareClosePrice(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			logrus.Info("stream ended (ctx done)")
 			return
-		default:
-			s.positionManager.Mu.RLock()
-			for _, OpenedPositionsMap := range s.positionManager.OpenedPositions {
-				for PositionID, OpenedPosition := range OpenedPositionsMap {
-					if !s.positionManager.Closed[PositionID] {
-						continue
-					}
-					position, err := s.rps.GetPositionByID(ctx, PositionID)
-					if err != nil {
-						logrus.Errorf("Error getting position: %v", err)
-					}
-					share, err := s.priceServiceRps.AddSubscriber(ctx, []string{position.ShareName})
-					if err != nil {
-						logrus.Errorf("Error getting share: %v", err)
-					}
-					fmt.Println(
-						"Position id -> ", PositionID,
-						"ShareOpenPrice -> ", OpenedPosition.ShareOpenPrice,
-						"CurrentSharePrice -> ", share.SharePrice,
-						"ShareClosePrice -> ", OpenedPosition.ShareClosePrice)
-				}
+		case <-ticker.C:
+			s.checkTriggers(ctx)
+			s.checkMargins(ctx)
+		}
+	}
+}
 
+// checkTriggers method fetches current prices of all watched shares and closes triggered positions
+func (s *TradingService) checkTriggers(ctx context.Context) {
+	openedPositions := s.getOpenedPositions()
+	prices := make(map[string]decimal.Decimal)
+	for _, openedPosition := range openedPositions {
+		if openedPosition.ShareClosePrice.IsZero() && openedPosition.TakeProfit.IsZero() {
+			continue
+		}
+		price, ok := prices[openedPosition.ShareName]
+		if !ok {
+			var err error
+			price, err = s.getSharePrice(ctx, openedPosition.ShareName)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"ShareName": openedPosition.ShareName}).Errorf("getSharePrice: %v", err)
+				continue
 			}
-			s.positionManager.Mu.RUnlock()
+			prices[openedPosition.ShareName] = price
+		}
+		if openedPosition.TrailingStopType != model.TrailingStopNone {
+			s.moveTrailingStop(ctx, openedPosition, price)
+		}
+		reason, triggered := checkTrigger(openedPosition, price)
+		if !triggered {
+			continue
+		}
+		err := s.closeTriggeredPosition(ctx, openedPosition, reason, price)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"PositionID": openedPosition.PositionID, "Reason": reason}).Errorf("closeTriggeredPosition: %v", err)
 		}
 	}
 }
 
-// PublishToAllOpenedPositions
-func (s *TradingService) PublishToAllOpenedPositions(ctx context.Context) {
-	for {
-		select {
-		case <-ctx.Done():
-			logrus.Info("stream ended (ctx done)")
-		default:
-			for PositionID, OpenedPosition := range s.positionManager.OpenedPositions {
-				s.positionManager.Mu.Lock()
-				position, err := s.rps.GetPositionByID(ctx, PositionID)
-				if err != nil {
-					logrus.Errorf("Error getting position: %v", err)
-					return
-				}
-				share, err := s.priceServiceRps.AddSubscriber(ctx, []string{position.ShareName})
-				if err != nil {
-					logrus.Errorf("Error getting share: %v", err)
-					return
-				}
-				fmt.Println("Position id -> ", PositionID, "ShareOpenPrice -> ", OpenedPosition[PositionID].ShareOpenPrice, "CurrentSharePrice -> ", share.SharePrice, "ShareClosePrice -> ", OpenedPosition[PositionID].ShareClosePrice)
-				s.positionManager.Mu.Unlock()
+// getSharePrice method returns current price of the share rounded to precision of its instrument
+// Failure to receive the price is returned as model.ErrPriceUnavailable, so the request can be retried later
+func (s *TradingService) getSharePrice(ctx context.Context, shareName string) (decimal.Decimal, error) {
+	share, err := s.priceServiceRps.AddSubscriber(ctx, []string{shareName})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("AddSubscriber: %w: %v", model.ErrPriceUnavailable, err)
+	}
+	return s.precision(shareName).Price(share.SharePrice), nil
+}
+
+// getOpenedPositions method returns a snapshot of all opened positions from position manager
+func (s *TradingService) getOpenedPositions() []*model.OpenedPosition {
+	s.positionManager.Mu.RLock()
+	defer s.positionManager.Mu.RUnlock()
+	var openedPositions []*model.OpenedPosition
+	for _, profilePositions := range s.positionManager.OpenedPositions {
+		for _, openedPosition := range profilePositions {
+			if s.positionManager.Closed[openedPosition.PositionID] {
+				continue
 			}
+			copied := *openedPosition
+			openedPositions = append(openedPositions, &copied)
 		}
 	}
+	return openedPositions
 }
 
-// CheckForTakeProfitAndStopLoss function checks if price reaches given stop loss or take profit
-func (s *TradingService) CheckForTakeProfitAndStopLoss(ctx context.Context) {
-	for {
+// moveTrailingStop method moves trailing stop of the position after the price if it moved favorably
+// New level is persisted so it survives restarts, failure of persisting is only logged as next move will store it again
+func (s *TradingService) moveTrailingStop(ctx context.Context, openedPosition *model.OpenedPosition, currentSharePrice decimal.Decimal) {
+	stopLoss := calculateTrailingStop(openedPosition.IsLong, openedPosition.TrailingStopType, openedPosition.TrailingStopDistance, currentSharePrice, s.precision(openedPosition.ShareName))
+	if openedPosition.IsLong && stopLoss.LessThanOrEqual(openedPosition.ShareClosePrice) || !openedPosition.IsLong && stopLoss.GreaterThanOrEqual(openedPosition.ShareClosePrice) {
+		return
+	}
+	s.positionManager.Mu.Lock()
+	managed, ok := s.positionManager.OpenedPositions[openedPosition.ProfileID][openedPosition.PositionID]
+	if ok {
+		managed.ShareClosePrice = stopLoss
+	}
+	s.positionManager.Mu.Unlock()
+	if !ok {
+		return
+	}
+	openedPosition.ShareClosePrice = stopLoss
+	err := s.rps.UpdateStopLoss(ctx, openedPosition.PositionID, stopLoss)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"PositionID": openedPosition.PositionID, "StopLoss": stopLoss}).Errorf("UpdateStopLoss: %v", err)
+	}
+}
+
+// closeTriggeredPosition method closes a position by its triggered level
+func (s *TradingService) closeTriggeredPosition(ctx context.Context, openedPosition *model.OpenedPosition, reason model.CloseReason, executionPrice decimal.Decimal) error {
+	if !s.markPositionClosing(openedPosition.PositionID) {
+		return nil
+	}
+	position, err := s.rps.GetPositionByID(ctx, openedPosition.PositionID)
+	if err != nil {
+		s.unmarkPositionClosing(openedPosition.PositionID)
+		return fmt.Errorf("GetPositionByID: %w", err)
+	}
+	// trailing stop in database may lag behind the one which was hit
+	position.StopLoss = openedPosition.ShareClosePrice
+	profitAndLoss, err := s.closePosition(ctx, position, executionPrice, reason)
+	if err != nil {
+		s.unmarkPositionClosing(openedPosition.PositionID)
+		return fmt.Errorf("closePosition: %w", err)
+	}
+	event := newTriggerEvent(position, reason, executionPrice, profitAndLoss.GrossPercent)
+	logrus.WithFields(logrus.Fields{
+		"PositionID":     event.PositionID,
+		"Reason":         event.Reason,
+		"TriggerPrice":   event.TriggerPrice,
+		"ExecutionPrice": event.ExecutionPrice,
+		"PnL":            event.PnL,
+		"NetPnL":         profitAndLoss.NetPercent,
+	}).Info("position closed by trigger")
+	return nil
+}
 
-		s.positionManager.Mu.RLock()
-		for ID, openedPosition := range s.positionManager.OpenedPositions {
-			select {
-			case <-ctx.Done():
-				logrus.Info("stream ended (ctx done)")
-				return
-			default:
-				position, err := s.rps.GetPositionByID(ctx, ID)
-				if err != nil {
-					return
-				}
-				fmt.Println(position, openedPosition)
+// GetPosition method returns the position of given ID with its current mark price and unrealized PnL
+// Closed position is returned with its stored exit price and realized PnL
+func (s *TradingService) GetPosition(ctx context.Context, positionID uuid.UUID) (*model.PositionDetails, error) {
+	position, err := s.rps.GetPositionByID(ctx, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("GetPositionByID: %w", err)
+	}
+	details, err := s.getPositionsDetails(ctx, []*model.Position{position})
+	if err != nil {
+		return nil, fmt.Errorf("getPositionsDetails: %w", err)
+	}
+	return details[0], nil
+}
 
+// ListPositions method returns a page of positions matching given filter and a cursor of the next page
+func (s *TradingService) ListPositions(ctx context.Context, filter *model.PositionFilter) ([]*model.PositionDetails, uuid.UUID, error) {
+	if filter.Limit <= 0 || filter.Limit > maxPositionsLimit {
+		filter.Limit = defaultPositionsLimit
+	}
+	limit := filter.Limit
+	// requesting one extra position to find out if there is a next page
+	filter.Limit++
+	positions, err := s.rps.ListPositions(ctx, filter)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("ListPositions: %w", err)
+	}
+	nextCursor := uuid.Nil
+	if len(positions) > limit {
+		positions = positions[:limit]
+		nextCursor = positions[limit-1].ID
+	}
+	details, err := s.getPositionsDetails(ctx, positions)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("getPositionsDetails: %w", err)
+	}
+	return details, nextCursor, nil
+}
+
+// getPositionsDetails method fetches current prices of given positions and calculates their unrealized PnL
+// Result of closed positions is stored, so their prices are not fetched
+func (s *TradingService) getPositionsDetails(ctx context.Context, positions []*model.Position) ([]*model.PositionDetails, error) {
+	prices := make(map[string]decimal.Decimal)
+	details := make([]*model.PositionDetails, 0, len(positions))
+	for _, position := range positions {
+		if position.Status == model.PositionStatusClosed {
+			details = append(details, &model.PositionDetails{Position: position, MarkPrice: position.ExitPrice})
+			continue
+		}
+		price, ok := prices[position.ShareName]
+		if !ok {
+			var err error
+			price, err = s.getSharePrice(ctx, position.ShareName)
+			if err != nil {
+				return nil, fmt.Errorf("getSharePrice: %w", err)
 			}
+			prices[position.ShareName] = price
 		}
-		s.positionManager.Mu.RUnlock()
+		PnL, PnLPercent := calculateUnrealizedPnL(position, price, s.rounding)
+		details = append(details, &model.PositionDetails{
+			Position:             position,
+			MarkPrice:            price,
+			UnrealizedPnL:        PnL,
+			UnrealizedPnLPercent: PnLPercent,
+		})
 	}
+	return details, nil
 }
 
-// CheckForServerUpdates function checks if server updates
-func (s *TradingService) CheckForServerUpdates() {
-
+// ListClosedTrades method returns a page of closed positions of the profile and a cursor of the next page
+func (s *TradingService) ListClosedTrades(ctx context.Context, filter *model.ClosedTradeFilter) ([]*model.Position, uuid.UUID, error) {
+	if filter.Limit <= 0 || filter.Limit > maxPositionsLimit {
+		filter.Limit = defaultPositionsLimit
+	}
+	limit := filter.Limit
+	// requesting one extra trade to find out if there is a next page
+	filter.Limit++
+	trades, err := s.rps.ListClosedTrades(ctx, filter)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("ListClosedTrades: %w", err)
+	}
+	nextCursor := uuid.Nil
+	if len(trades) > limit {
+		trades = trades[:limit]
+		nextCursor = trades[limit-1].ID
+	}
+	return trades, nextCursor, nil
 }
 
 // Calculations
 
-// CalculateProfitAndLoss function calculates profit and loss for given position
-func calculateProfitAndLoss(ctx context.Context, position *model.Position, currentSharePrice, balance float64) (float64, float64, error) {
-	// initializing given float64 variables
-	totalDecimal := decimal.NewFromFloatWithExponent(position.Total, -2)
-	currentSharePriceDecimal := decimal.NewFromFloatWithExponent(currentSharePrice, -2)
-	shareAmountDecimal := decimal.NewFromFloatWithExponent(position.ShareAmount, -4)
-	balanceDecimal := decimal.NewFromFloatWithExponent(balance, -2)
+// hundred is a multiplier of percents
+var hundred = decimal.NewFromInt(100)
+
+// calculateUnrealizedPnL function calculates unrealized PnL of the position by given mark price in money and percents
+func calculateUnrealizedPnL(position *model.Position, markPrice decimal.Decimal, rounding *model.RoundingRules) (PnL, PnLPercent decimal.Decimal) {
+	PnL = calculatePriceChange(position, markPrice)
+	if position.Total.IsZero() {
+		return rounding.Money(PnL), decimal.Zero
+	}
+	return rounding.Money(PnL), PnL.Div(position.Total).Mul(hundred).Round(model.PercentScale)
+}
+
+// checkTrigger function checks if current share price crosses stop loss or take profit of the position
+func checkTrigger(openedPosition *model.OpenedPosition, currentSharePrice decimal.Decimal) (model.CloseReason, bool) {
+	stopLoss := openedPosition.ShareClosePrice
+	takeProfit := openedPosition.TakeProfit
+	if openedPosition.IsLong {
+		if stopLoss.IsPositive() && currentSharePrice.LessThanOrEqual(stopLoss) {
+			return model.CloseReasonStopLoss, true
+		}
+		if takeProfit.IsPositive() && currentSharePrice.GreaterThanOrEqual(takeProfit) {
+			return model.CloseReasonTakeProfit, true
+		}
+		return "", false
+	}
+	if stopLoss.IsPositive() && currentSharePrice.GreaterThanOrEqual(stopLoss) {
+		return model.CloseReasonStopLoss, true
+	}
+	if takeProfit.IsPositive() && currentSharePrice.LessThanOrEqual(takeProfit) {
+		return model.CloseReasonTakeProfit, true
+	}
+	return "", false
+}
+
+// calculatePriceChange function calculates how the value of shares of the position changed since they were bought
+// by given share price in direction of the position, it is the same for settlement and unrealized PnL
+func calculatePriceChange(position *model.Position, sharePrice decimal.Decimal) decimal.Decimal {
+	change := sharePrice.Sub(position.SharePrice).Mul(position.ShareAmount)
+	if !position.IsLong {
+		return change.Neg()
+	}
+	return change
+}
+
+// CalculateProfitAndLoss function calculates settlement amount and profit and loss in percents for given position
+// Position returns its margin (Total) plus the difference between current value of shares and the value they were
+// bought for (open price multiplied by amount of shares) in direction of the position, but never less than zero
+func calculateProfitAndLoss(ctx context.Context, position *model.Position, currentSharePrice decimal.Decimal, rounding *model.RoundingRules) (decimal.Decimal, decimal.Decimal, error) {
+	if position.Total.IsZero() {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("total of position %v is zero", position.ID)
+	}
+
+	// calculating settlement amount
+	settlement := position.Total.Add(calculatePriceChange(position, currentSharePrice))
+	if settlement.IsNegative() {
+		settlement = decimal.Zero
+	}
+	settlement = rounding.Money(settlement)
 
 	// calculating PnL
-	currentTotalDecimal := currentSharePriceDecimal.Mul(shareAmountDecimal)
-	pnl := currentTotalDecimal.Div(totalDecimal).Mul(decimal.New(100, -2))
-	actualPnL := pnl.Sub(decimal.New(100, -2))
-	actualPnL = actualPnL.Mul(decimal.New(100, 0))
+	PnL := settlement.Sub(position.Total).Div(position.Total).Mul(hundred).Round(model.PercentScale)
+	logrus.WithFields(logrus.Fields{"IsLong": position.IsLong, "OldTotal": position.Total, "NewTotal": settlement}).Debugf("PnL: %v%%", PnL)
 
-	// converting back to float
-	actualFloatPnL, _ := actualPnL.Float64()
-	currentTotal, _ := currentTotalDecimal.Float64()
-	fmt.Printf("old total: %f \nnew total: %f \nPnL: %f%% \n", position.Total, currentTotal, actualFloatPnL)
+	return settlement, PnL, nil
+}
 
-	// calculating of balance
-	balanceDecimal = balanceDecimal.Add(currentTotalDecimal)
-	balance, _ = balanceDecimal.Float64()
-	return balance, actualFloatPnL, nil
+// calculateClosedShareAmount function calculates amount of shares to close for given part of the position
+func calculateClosedShareAmount(position *model.Position, part model.ClosePart, precision model.Precision) (decimal.Decimal, error) {
+	switch {
+	case part.ShareAmount.IsNegative() || part.Percent.IsNegative():
+		return decimal.Zero, model.NewValidationError("shareAmount", "part of position to close can't be negative")
+	case part.ShareAmount.IsPositive() && part.Percent.IsPositive():
+		return decimal.Zero, model.NewValidationError("percent", "part of position to close must be set either by amount of shares or by percentage")
+	case part.Percent.GreaterThan(hundred):
+		return decimal.Zero, model.NewValidationError("percent", "percentage %v of position to close is more than 100", part.Percent)
+	case part.ShareAmount.GreaterThan(position.ShareAmount):
+		return decimal.Zero, model.NewValidationError("shareAmount", "amount of shares %v to close is more than %v in position", part.ShareAmount, position.ShareAmount)
+	case part.Percent.IsPositive():
+		shareAmount := precision.Amount(position.ShareAmount.Mul(part.Percent).Div(hundred))
+		if shareAmount.IsZero() {
+			return decimal.Zero, model.NewValidationError("percent", "percentage %v of position is less than minimal amount of shares", part.Percent)
+		}
+		return shareAmount, nil
+	case part.ShareAmount.IsPositive():
+		shareAmount := precision.Amount(part.ShareAmount)
+		if shareAmount.IsZero() {
+			return decimal.Zero, model.NewValidationError("shareAmount", "amount of shares %v is less than minimal amount of shares", part.ShareAmount)
+		}
+		return shareAmount, nil
+	}
+	return position.ShareAmount, nil
 }
 
-// calculateAmountOfShares calculates the amount of shares for given amount of money
-func calculateAmountOfShares(ctx context.Context, moneyAmount float64, sharePrice float64) (shareAmount float64, err error) {
-	moneyAmountDecimal := decimal.NewFromFloatWithExponent(moneyAmount, -2)
-	sharePriceDecimal := decimal.NewFromFloatWithExponent(sharePrice, -2)
+// increasePosition function returns a copy of the position with added shares bought for total by given share price
+func increasePosition(position *model.Position, total, shareAmount, sharePrice decimal.Decimal, precision model.Precision) *model.Position {
+	newShareAmount := position.ShareAmount.Add(shareAmount)
+	averagePrice := position.SharePrice.Mul(position.ShareAmount).
+		Add(sharePrice.Mul(shareAmount)).
+		Div(newShareAmount)
+
+	increased := *position
+	increased.SharePrice = precision.Price(averagePrice)
+	increased.ShareAmount = newShareAmount
+	increased.Total = position.Total.Add(total)
+	return &increased
+}
 
-	shareAmountDecimal := moneyAmountDecimal.Div(sharePriceDecimal)
+// splitPosition function splits the position into closed part of given amount of shares and the remaining part
+// Total and commission of opening of the position are divided in proportion to amount of shares
+func splitPosition(position *model.Position, shareAmount decimal.Decimal, rounding *model.RoundingRules) (closedPart, remaining *model.Position) {
+	closedTotal := rounding.Money(position.Total.Mul(shareAmount).Div(position.ShareAmount))
+	closedOpenFee := rounding.Money(position.OpenFee.Mul(shareAmount).Div(position.ShareAmount))
+
+	closed := *position
+	closed.ID = uuid.New()
+	closed.ParentID = &position.ID
+	closed.ShareAmount = shareAmount
+	closed.Total = closedTotal
+	closed.OpenFee = closedOpenFee
+	closed.Version = 0
+
+	rest := *position
+	rest.ShareAmount = position.ShareAmount.Sub(shareAmount)
+	rest.Total = position.Total.Sub(closedTotal)
+	rest.OpenFee = position.OpenFee.Sub(closedOpenFee)
+	return &closed, &rest
+}
 
-	shareAmount, _ = shareAmountDecimal.Float64()
-	return shareAmount, nil
+// calculateTrailingStop function calculates a stop loss level trailing given share price by the distance
+func calculateTrailingStop(isLong bool, trailingStopType model.TrailingStopType, distance, sharePrice decimal.Decimal, precision model.Precision) decimal.Decimal {
+	if trailingStopType == model.TrailingStopPercent {
+		distance = sharePrice.Mul(distance).Div(hundred)
+	}
+	stopLoss := sharePrice.Sub(distance)
+	if !isLong {
+		stopLoss = sharePrice.Add(distance)
+	}
+	return precision.Price(stopLoss)
 }
 
-// checkIfEnoughMoneyOnBalance function returns error if not enough money on balance
-func checkIfEnoughMoneyOnBalance(balance *model.Balance, moneyAmount float64) (*model.Balance, error) {
-	balanceDecimal := decimal.NewFromFloatWithExponent(balance.Balance, -2)
-	moneyAmountDecimal := decimal.NewFromFloatWithExponent(moneyAmount, -2)
-	greater := balanceDecimal.GreaterThanOrEqual(moneyAmountDecimal)
-	if !greater {
-		return nil, fmt.Errorf("GreaterThanOrEqual:%v", greater)
+// validateTrailingStop function checks mode and distance of trailing stop, fixed stop loss can't be set together with it
+func validateTrailingStop(position *model.Position) error {
+	switch position.TrailingStopType {
+	case model.TrailingStopNone:
+		return nil
+	case model.TrailingStopAmount:
+		if position.TrailingStopDistance.GreaterThanOrEqual(position.SharePrice) {
+			return model.NewValidationError("trailingStopDistance", "trailing stop distance %v must be less than open price %v", position.TrailingStopDistance, position.SharePrice)
+		}
+	case model.TrailingStopPercent:
+		if position.TrailingStopDistance.GreaterThanOrEqual(hundred) {
+			return model.NewValidationError("trailingStopDistance", "trailing stop distance %v%% must be less than 100%%", position.TrailingStopDistance)
+		}
+	default:
+		return model.NewValidationError("trailingStopType", "unknown trailing stop type %q", position.TrailingStopType)
 	}
-	updatedBalanceDecimal := balanceDecimal.Sub(moneyAmountDecimal)
+	if !position.TrailingStopDistance.IsPositive() {
+		return model.NewValidationError("trailingStopDistance", "trailing stop distance must be positive")
+	}
+	if !position.StopLoss.IsZero() {
+		return model.NewValidationError("stopLoss", "stop loss can't be set together with trailing stop")
+	}
+	return nil
+}
 
-	balance.Balance, _ = updatedBalanceDecimal.Float64()
+// validateStopLossAndTakeProfit function checks stop loss and take profit ordering around open share price
+// Long position requires stop loss below and take profit above open price, short position requires the opposite
+func validateStopLossAndTakeProfit(position *model.Position) error {
+	if position.StopLoss.IsNegative() || position.TakeProfit.IsNegative() {
+		return model.NewValidationError("stopLoss", "stop loss and take profit can't be negative")
+	}
+	if position.IsLong {
+		if position.StopLoss.IsPositive() && position.StopLoss.GreaterThanOrEqual(position.SharePrice) {
+			return model.NewValidationError("stopLoss", "stop loss %v of long position must be below open price %v", position.StopLoss, position.SharePrice)
+		}
+		if position.TakeProfit.IsPositive() && position.TakeProfit.LessThanOrEqual(position.SharePrice) {
+			return model.NewValidationError("takeProfit", "take profit %v of long position must be above open price %v", position.TakeProfit, position.SharePrice)
+		}
+		return nil
+	}
+	if position.StopLoss.IsPositive() && position.StopLoss.LessThanOrEqual(position.SharePrice) {
+		return model.NewValidationError("stopLoss", "stop loss %v of short position must be above open price %v", position.StopLoss, position.SharePrice)
+	}
+	if position.TakeProfit.IsPositive() && position.TakeProfit.GreaterThanOrEqual(position.SharePrice) {
+		return model.NewValidationError("takeProfit", "take profit %v of short position must be below open price %v", position.TakeProfit, position.SharePrice)
+	}
+	return nil
+}
 
-	return balance, nil
+// calculateAmountOfShares calculates the amount of shares for given amount of money truncated to precision of the instrument
+func calculateAmountOfShares(ctx context.Context, moneyAmount, sharePrice decimal.Decimal, precision model.Precision) (decimal.Decimal, error) {
+	if !sharePrice.IsPositive() {
+		return decimal.Zero, fmt.Errorf("share price %v must be positive", sharePrice)
+	}
+	shareAmount := precision.Amount(moneyAmount.Div(sharePrice))
+	if shareAmount.IsZero() {
+		return decimal.Zero, model.NewValidationError("total", "%v is not enough to buy minimal amount of shares by price %v", moneyAmount, sharePrice)
+	}
+	return shareAmount, nil
 }
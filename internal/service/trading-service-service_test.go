@@ -0,0 +1,512 @@
+// Package service contains business-logic methods
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eugenshima/trading-service/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// testRounding is rounding of money to cents used by tests
+var testRounding = &model.RoundingRules{MoneyScale: 2}
+
+// dec function parses decimal of a test case
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func TestCalculateProfitAndLoss(t *testing.T) {
+	tests := []struct {
+		name       string
+		position   *model.Position
+		price      string
+		settlement string
+		PnL        string
+	}{
+		{
+			name:       "long in profit",
+			position:   &model.Position{IsLong: true, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "12",
+			settlement: "120",
+			PnL:        "20",
+		},
+		{
+			name:       "long in loss",
+			position:   &model.Position{IsLong: true, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "7.5",
+			settlement: "75",
+			PnL:        "-25",
+		},
+		{
+			name:       "short in profit",
+			position:   &model.Position{IsLong: false, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "8",
+			settlement: "120",
+			PnL:        "20",
+		},
+		{
+			name:       "short in loss",
+			position:   &model.Position{IsLong: false, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "12",
+			settlement: "80",
+			PnL:        "-20",
+		},
+		{
+			name:       "short loss is limited by margin",
+			position:   &model.Position{IsLong: false, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "25",
+			settlement: "0",
+			PnL:        "-100",
+		},
+		{
+			name:       "leveraged long in profit",
+			position:   &model.Position{IsLong: true, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("50"), Leverage: dec("5")},
+			price:      "11",
+			settlement: "150",
+			PnL:        "50",
+		},
+		{
+			name:       "leveraged short in profit",
+			position:   &model.Position{IsLong: false, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("50"), Leverage: dec("5")},
+			price:      "9",
+			settlement: "150",
+			PnL:        "50",
+		},
+		{
+			name:       "leveraged long loss is limited by margin",
+			position:   &model.Position{IsLong: true, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("50"), Leverage: dec("5")},
+			price:      "7",
+			settlement: "0",
+			PnL:        "-100",
+		},
+		{
+			name:       "settlement is rounded to cents",
+			position:   &model.Position{IsLong: true, Total: dec("100"), SharePrice: dec("10"), ShareAmount: dec("10")},
+			price:      "10.0049",
+			settlement: "100.05",
+			PnL:        "0.05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement, PnL, err := calculateProfitAndLoss(context.Background(), tt.position, dec(tt.price), testRounding)
+			if err != nil {
+				t.Fatalf("calculateProfitAndLoss: %v", err)
+			}
+			if !settlement.Equal(dec(tt.settlement)) {
+				t.Errorf("settlement is %v, want %v", settlement, tt.settlement)
+			}
+			if !PnL.Equal(dec(tt.PnL)) {
+				t.Errorf("PnL is %v%%, want %v%%", PnL, tt.PnL)
+			}
+		})
+	}
+}
+
+func TestProfitAndLossAgreesWithUnrealizedPnL(t *testing.T) {
+	tests := []struct {
+		name     string
+		position *model.Position
+		price    string
+	}{
+		{name: "long", position: &model.Position{IsLong: true, SharePrice: dec("10"), Total: dec("100"), ShareAmount: dec("10")}, price: "11.37"},
+		{name: "short", position: &model.Position{IsLong: false, SharePrice: dec("10"), Total: dec("100"), ShareAmount: dec("10")}, price: "9.21"},
+		{
+			name:     "truncated amount of shares",
+			position: &model.Position{IsLong: false, SharePrice: dec("30"), Total: dec("100"), ShareAmount: dec("3")},
+			price:    "30",
+		},
+		{
+			name:     "leveraged with margin rounded to cents",
+			position: &model.Position{IsLong: true, SharePrice: dec("33.33"), Total: dec("33.33"), ShareAmount: dec("3"), Leverage: dec("3")},
+			price:    "35",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement, _, err := calculateProfitAndLoss(context.Background(), tt.position, dec(tt.price), testRounding)
+			if err != nil {
+				t.Fatalf("calculateProfitAndLoss: %v", err)
+			}
+			unrealizedPnL, _ := calculateUnrealizedPnL(tt.position, dec(tt.price), testRounding)
+			if realizedPnL := settlement.Sub(tt.position.Total); !realizedPnL.Equal(unrealizedPnL) {
+				t.Errorf("PnL of closing is %v, unrealized PnL is %v", realizedPnL, unrealizedPnL)
+			}
+		})
+	}
+}
+
+func TestCalculateProfitAndLossZeroTotal(t *testing.T) {
+	position := &model.Position{ID: uuid.New(), IsLong: true, ShareAmount: dec("10")}
+	_, _, err := calculateProfitAndLoss(context.Background(), position, dec("10"), testRounding)
+	if err == nil {
+		t.Fatal("calculateProfitAndLoss returned no error for position with zero total")
+	}
+}
+
+func TestCheckTrigger(t *testing.T) {
+	long := &model.OpenedPosition{IsLong: true, ShareClosePrice: dec("90"), TakeProfit: dec("120")}
+	short := &model.OpenedPosition{IsLong: false, ShareClosePrice: dec("110"), TakeProfit: dec("80")}
+	unprotected := &model.OpenedPosition{IsLong: true}
+	tests := []struct {
+		name     string
+		position *model.OpenedPosition
+		price    string
+		reason   model.CloseReason
+		ok       bool
+	}{
+		{name: "long between levels", position: long, price: "100"},
+		{name: "long at stop loss", position: long, price: "90", reason: model.CloseReasonStopLoss, ok: true},
+		{name: "long below stop loss", position: long, price: "85", reason: model.CloseReasonStopLoss, ok: true},
+		{name: "long at take profit", position: long, price: "120", reason: model.CloseReasonTakeProfit, ok: true},
+		{name: "short between levels", position: short, price: "100"},
+		{name: "short at stop loss", position: short, price: "110", reason: model.CloseReasonStopLoss, ok: true},
+		{name: "short at take profit", position: short, price: "80", reason: model.CloseReasonTakeProfit, ok: true},
+		{name: "short above take profit", position: short, price: "95"},
+		{name: "levels are not set", position: unprotected, price: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := checkTrigger(tt.position, dec(tt.price))
+			if reason != tt.reason || ok != tt.ok {
+				t.Errorf("checkTrigger at %v = (%q, %v), want (%q, %v)", tt.price, reason, ok, tt.reason, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGetPositionsDetailsOfClosedPosition(t *testing.T) {
+	// price service is not set, so fetching a price of closed position would panic
+	s := &TradingService{rounding: testRounding}
+	position := &model.Position{
+		IsLong: true, ShareName: "AAPL", SharePrice: dec("10"), Total: dec("100"), ShareAmount: dec("10"),
+		Status: model.PositionStatusClosed, ExitPrice: dec("12"), RealizedPnL: dec("20"),
+	}
+	details, err := s.getPositionsDetails(context.Background(), []*model.Position{position})
+	if err != nil {
+		t.Fatalf("getPositionsDetails: %v", err)
+	}
+	if !details[0].MarkPrice.Equal(dec("12")) {
+		t.Errorf("mark price is %v, want exit price 12", details[0].MarkPrice)
+	}
+	if !details[0].UnrealizedPnL.IsZero() || !details[0].UnrealizedPnLPercent.IsZero() {
+		t.Errorf("unrealized PnL is %v (%v%%), want zero", details[0].UnrealizedPnL, details[0].UnrealizedPnLPercent)
+	}
+	if !details[0].Position.RealizedPnL.Equal(dec("20")) {
+		t.Errorf("realized PnL is %v, want 20", details[0].Position.RealizedPnL)
+	}
+}
+
+// closedTradesRepository struct is a trading repository which returns given number of closed trades
+type closedTradesRepository struct {
+	TradingRepository
+	trades int
+	limit  int
+}
+
+// ListClosedTrades method records requested limit and returns at most that many trades
+func (r *closedTradesRepository) ListClosedTrades(_ context.Context, filter *model.ClosedTradeFilter) ([]*model.Position, error) {
+	r.limit = filter.Limit
+	var trades []*model.Position
+	for i := 0; i < r.trades && i < filter.Limit; i++ {
+		trades = append(trades, &model.Position{ID: uuid.New(), Status: model.PositionStatusClosed})
+	}
+	return trades, nil
+}
+
+func TestListClosedTrades(t *testing.T) {
+	tests := []struct {
+		name        string
+		trades      int
+		limit       int
+		requested   int
+		returned    int
+		hasNextPage bool
+	}{
+		{name: "last page", trades: 3, limit: 5, requested: 6, returned: 3},
+		{name: "full page with next page", trades: 10, limit: 5, requested: 6, returned: 5, hasNextPage: true},
+		{name: "exactly one page", trades: 5, limit: 5, requested: 6, returned: 5},
+		{name: "default limit", trades: 0, limit: 0, requested: defaultPositionsLimit + 1},
+		{name: "limit above maximum", trades: 0, limit: maxPositionsLimit + 1, requested: defaultPositionsLimit + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rps := &closedTradesRepository{trades: tt.trades}
+			s := &TradingService{rps: rps}
+			trades, nextCursor, err := s.ListClosedTrades(context.Background(), &model.ClosedTradeFilter{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("ListClosedTrades: %v", err)
+			}
+			if rps.limit != tt.requested {
+				t.Errorf("%d trades were requested, want %d", rps.limit, tt.requested)
+			}
+			if len(trades) != tt.returned {
+				t.Errorf("%d trades were returned, want %d", len(trades), tt.returned)
+			}
+			if hasNextPage := nextCursor != uuid.Nil; hasNextPage != tt.hasNextPage {
+				t.Errorf("next page is %v, want %v", hasNextPage, tt.hasNextPage)
+			}
+			if tt.hasNextPage && nextCursor != trades[len(trades)-1].ID {
+				t.Errorf("next cursor is %v, want ID of the last returned trade", nextCursor)
+			}
+		})
+	}
+}
+
+func TestCalculateTrailingStop(t *testing.T) {
+	tests := []struct {
+		name             string
+		isLong           bool
+		trailingStopType model.TrailingStopType
+		distance         string
+		price            string
+		precision        model.Precision
+		stopLoss         string
+	}{
+		{name: "long by amount", isLong: true, trailingStopType: model.TrailingStopAmount, distance: "5", price: "100", stopLoss: "95"},
+		{name: "short by amount", isLong: false, trailingStopType: model.TrailingStopAmount, distance: "5", price: "100", stopLoss: "105"},
+		{name: "long by percent", isLong: true, trailingStopType: model.TrailingStopPercent, distance: "10", price: "200", stopLoss: "180"},
+		{name: "short by percent", isLong: false, trailingStopType: model.TrailingStopPercent, distance: "10", price: "200", stopLoss: "220"},
+		{
+			name: "rounded to tick size", isLong: true, trailingStopType: model.TrailingStopPercent, distance: "3", price: "101",
+			precision: model.Precision{TickSize: dec("0.5")}, stopLoss: "98",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopLoss := calculateTrailingStop(tt.isLong, tt.trailingStopType, dec(tt.distance), dec(tt.price), tt.precision)
+			if !stopLoss.Equal(dec(tt.stopLoss)) {
+				t.Errorf("stop loss is %v, want %v", stopLoss, tt.stopLoss)
+			}
+		})
+	}
+}
+
+func TestValidateTrailingStop(t *testing.T) {
+	tests := []struct {
+		name     string
+		position *model.Position
+		valid    bool
+	}{
+		{name: "no trailing stop", position: &model.Position{SharePrice: dec("100")}, valid: true},
+		{name: "by amount", position: &model.Position{SharePrice: dec("100"), TrailingStopType: model.TrailingStopAmount, TrailingStopDistance: dec("5")}, valid: true},
+		{name: "by percent", position: &model.Position{SharePrice: dec("100"), TrailingStopType: model.TrailingStopPercent, TrailingStopDistance: dec("5")}, valid: true},
+		{name: "amount is not less than price", position: &model.Position{SharePrice: dec("100"), TrailingStopType: model.TrailingStopAmount, TrailingStopDistance: dec("100")}},
+		{name: "percent is not less than 100", position: &model.Position{SharePrice: dec("100"), TrailingStopType: model.TrailingStopPercent, TrailingStopDistance: dec("100")}},
+		{name: "zero distance", position: &model.Position{SharePrice: dec("100"), TrailingStopType: model.TrailingStopAmount}},
+		{name: "unknown type", position: &model.Position{SharePrice: dec("100"), TrailingStopType: "ticks", TrailingStopDistance: dec("5")}},
+		{
+			name:     "together with fixed stop loss",
+			position: &model.Position{SharePrice: dec("100"), StopLoss: dec("90"), TrailingStopType: model.TrailingStopAmount, TrailingStopDistance: dec("5")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTrailingStop(tt.position)
+			if tt.valid && err != nil {
+				t.Errorf("validateTrailingStop: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, model.ErrInvalidArgument) {
+				t.Errorf("validateTrailingStop returned %v, want invalid argument", err)
+			}
+		})
+	}
+}
+
+func TestCalculateClosedShareAmount(t *testing.T) {
+	position := &model.Position{ShareAmount: dec("10")}
+	precision := model.Precision{AmountScale: 2}
+	tests := []struct {
+		name        string
+		part        model.ClosePart
+		shareAmount string
+		valid       bool
+	}{
+		{name: "whole position", shareAmount: "10", valid: true},
+		{name: "by amount", part: model.ClosePart{ShareAmount: dec("4")}, shareAmount: "4", valid: true},
+		{name: "by percent", part: model.ClosePart{Percent: dec("25")}, shareAmount: "2.5", valid: true},
+		{name: "by all percents", part: model.ClosePart{Percent: dec("100")}, shareAmount: "10", valid: true},
+		{name: "percent is truncated to amount scale", part: model.ClosePart{Percent: dec("33.333")}, shareAmount: "3.33", valid: true},
+		{name: "amount is truncated to amount scale", part: model.ClosePart{ShareAmount: dec("1.239")}, shareAmount: "1.23", valid: true},
+		{name: "negative amount", part: model.ClosePart{ShareAmount: dec("-1")}},
+		{name: "negative percent", part: model.ClosePart{Percent: dec("-1")}},
+		{name: "both amount and percent", part: model.ClosePart{ShareAmount: dec("1"), Percent: dec("10")}},
+		{name: "more than 100 percents", part: model.ClosePart{Percent: dec("100.1")}},
+		{name: "more shares than in position", part: model.ClosePart{ShareAmount: dec("10.01")}},
+		{name: "percent is less than minimal amount", part: model.ClosePart{Percent: dec("0.01")}},
+		{name: "amount is less than minimal amount", part: model.ClosePart{ShareAmount: dec("0.001")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shareAmount, err := calculateClosedShareAmount(position, tt.part, precision)
+			if !tt.valid {
+				if !errors.Is(err, model.ErrInvalidArgument) {
+					t.Errorf("calculateClosedShareAmount returned %v, want invalid argument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("calculateClosedShareAmount: %v", err)
+			}
+			if !shareAmount.Equal(dec(tt.shareAmount)) {
+				t.Errorf("amount of shares to close is %v, want %v", shareAmount, tt.shareAmount)
+			}
+		})
+	}
+}
+
+func TestSplitPosition(t *testing.T) {
+	position := &model.Position{
+		ID: uuid.New(), IsLong: true, ShareName: "AAPL", SharePrice: dec("33"),
+		Total: dec("100"), ShareAmount: dec("3"), OpenFee: dec("1"), Version: 4,
+	}
+	closed, remaining := splitPosition(position, dec("1"), testRounding)
+
+	if closed.ID == position.ID || closed.ParentID == nil || *closed.ParentID != position.ID {
+		t.Errorf("closed part has ID %v and parent %v, want a new ID and parent %v", closed.ID, closed.ParentID, position.ID)
+	}
+	if closed.Version != 0 {
+		t.Errorf("closed part has version %d, want 0", closed.Version)
+	}
+	if remaining.ID != position.ID || remaining.Version != position.Version {
+		t.Errorf("remaining part has ID %v and version %d, want %v and %d", remaining.ID, remaining.Version, position.ID, position.Version)
+	}
+	tests := []struct {
+		name  string
+		value decimal.Decimal
+		want  string
+	}{
+		{name: "closed shares", value: closed.ShareAmount, want: "1"},
+		{name: "closed total", value: closed.Total, want: "33.33"},
+		{name: "closed open fee", value: closed.OpenFee, want: "0.33"},
+		{name: "remaining shares", value: remaining.ShareAmount, want: "2"},
+		{name: "remaining total", value: remaining.Total, want: "66.67"},
+		{name: "remaining open fee", value: remaining.OpenFee, want: "0.67"},
+	}
+	for _, tt := range tests {
+		if !tt.value.Equal(dec(tt.want)) {
+			t.Errorf("%s is %v, want %v", tt.name, tt.value, tt.want)
+		}
+	}
+	if !position.ShareAmount.Equal(dec("3")) || !position.Total.Equal(dec("100")) {
+		t.Errorf("original position was changed to %v shares for %v", position.ShareAmount, position.Total)
+	}
+}
+
+func TestIncreasePosition(t *testing.T) {
+	tests := []struct {
+		name        string
+		position    *model.Position
+		total       string
+		shareAmount string
+		price       string
+		sharePrice  string
+		amount      string
+		newTotal    string
+	}{
+		{
+			name:     "same amount by higher price",
+			position: &model.Position{SharePrice: dec("100"), ShareAmount: dec("10"), Total: dec("1000")},
+			total:    "1100", shareAmount: "10", price: "110",
+			sharePrice: "105", amount: "20", newTotal: "2100",
+		},
+		{
+			name:     "weighted by amount of shares",
+			position: &model.Position{SharePrice: dec("10"), ShareAmount: dec("3"), Total: dec("30")},
+			total:    "11", shareAmount: "1", price: "11",
+			sharePrice: "10.25", amount: "4", newTotal: "41",
+		},
+		{
+			name:     "average price is rounded to tick size",
+			position: &model.Position{SharePrice: dec("10"), ShareAmount: dec("2"), Total: dec("20")},
+			total:    "10.1", shareAmount: "1", price: "10.1",
+			sharePrice: "10.03", amount: "3", newTotal: "30.1",
+		},
+	}
+	precision := model.Precision{TickSize: dec("0.01")}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := *tt.position
+			increased := increasePosition(tt.position, dec(tt.total), dec(tt.shareAmount), dec(tt.price), precision)
+			if !increased.SharePrice.Equal(dec(tt.sharePrice)) {
+				t.Errorf("average price is %v, want %v", increased.SharePrice, tt.sharePrice)
+			}
+			if !increased.ShareAmount.Equal(dec(tt.amount)) {
+				t.Errorf("amount of shares is %v, want %v", increased.ShareAmount, tt.amount)
+			}
+			if !increased.Total.Equal(dec(tt.newTotal)) {
+				t.Errorf("total is %v, want %v", increased.Total, tt.newTotal)
+			}
+			if !tt.position.SharePrice.Equal(original.SharePrice) || !tt.position.ShareAmount.Equal(original.ShareAmount) {
+				t.Error("original position was changed")
+			}
+		})
+	}
+}
+
+func TestOpenPositionWithTruncatedShareAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		isLong bool
+	}{
+		{name: "long", isLong: true},
+		{name: "short", isLong: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileID := uuid.New()
+			balance := newMemoryBalance(profileID, dec("1000"))
+			s, _ := newMemoryService(newMemoryRepository(), balance, "AAPL", dec("30"), 0)
+			position := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: tt.isLong, Total: dec("100")}
+
+			err := s.OpenPosition(context.Background(), position, "")
+			if err != nil {
+				t.Fatalf("OpenPosition: %v", err)
+			}
+			if !position.ShareAmount.Equal(dec("3")) || !position.Total.Equal(dec("90")) {
+				t.Errorf("position has %v shares for %v, want 3 shares for 90", position.ShareAmount, position.Total)
+			}
+			if got := balance.balance(profileID); !got.Equal(dec("910")) {
+				t.Errorf("balance after opening is %v, want 910", got)
+			}
+
+			profitAndLoss, err := s.ClosePosition(context.Background(), position.ID, model.ClosePart{}, "")
+			if err != nil {
+				t.Fatalf("ClosePosition: %v", err)
+			}
+			if !profitAndLoss.Gross.IsZero() {
+				t.Errorf("PnL at unchanged price is %v, want 0", profitAndLoss.Gross)
+			}
+			if got := balance.balance(profileID); !got.Equal(dec("1000")) {
+				t.Errorf("balance after closing at unchanged price is %v, want 1000", got)
+			}
+		})
+	}
+}
+
+func TestIncreasePositionWithTruncatedShareAmount(t *testing.T) {
+	profileID := uuid.New()
+	balance := newMemoryBalance(profileID, dec("1000"))
+	s, _ := newMemoryService(newMemoryRepository(), balance, "AAPL", dec("30"), 0)
+	position := &model.Position{ID: uuid.New(), ProfileID: profileID, ShareName: "AAPL", IsLong: true, Total: dec("90")}
+	err := s.OpenPosition(context.Background(), position, "")
+	if err != nil {
+		t.Fatalf("OpenPosition: %v", err)
+	}
+
+	increased, err := s.IncreasePosition(context.Background(), position.ID, dec("50"))
+	if err != nil {
+		t.Fatalf("IncreasePosition: %v", err)
+	}
+	if !increased.ShareAmount.Equal(dec("4")) || !increased.Total.Equal(dec("120")) {
+		t.Errorf("increased position has %v shares for %v, want 4 shares for 120", increased.ShareAmount, increased.Total)
+	}
+	if got := balance.balance(profileID); !got.Equal(dec("880")) {
+		t.Errorf("balance after increasing is %v, want 880", got)
+	}
+}
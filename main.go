@@ -5,11 +5,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"strconv"
+	"time"
 
 	balanceServiceProto "github.com/eugenshima/balance/proto"
 	priceServiceProto "github.com/eugenshima/price-service/proto"
 	"github.com/eugenshima/trading-service/internal/config"
 	"github.com/eugenshima/trading-service/internal/handlers"
+	"github.com/eugenshima/trading-service/internal/migrations"
 	"github.com/eugenshima/trading-service/internal/model"
 	"github.com/eugenshima/trading-service/internal/repository"
 	"github.com/eugenshima/trading-service/internal/service"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/go-playground/validator"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -40,6 +45,50 @@ func NewDBPsql(env string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// runMigrate function runs "migrate up", "migrate down [steps]" or "migrate status" subcommand
+func runMigrate(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [steps]|status")
+	}
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("Up: %w", err)
+		}
+		fmt.Printf("applied %d migrations\n", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("steps must be a positive number, got %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return fmt.Errorf("Down: %w", err)
+		}
+		fmt.Printf("reverted %d migrations\n", len(reverted))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("Status: %w", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q, usage: migrate up|down [steps]|status", args[0])
+	}
+	return nil
+}
+
 // nolint:staticcheck // noinspection
 func main() {
 	cfg, err := config.NewConfig()
@@ -53,6 +102,29 @@ func main() {
 		return
 	}
 
+	migrator, err := migrations.NewMigrator(pool)
+	if err != nil {
+		logrus.Errorf("NewMigrator: %v", err)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(context.Background(), migrator, os.Args[2:])
+		if err != nil {
+			logrus.Errorf("runMigrate: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if cfg.AutoMigrate {
+		_, err = migrator.Up(context.Background())
+		if err != nil {
+			logrus.Errorf("Up: %v", err)
+			return
+		}
+	} else {
+		logrus.Warn("AUTO_MIGRATE is disabled, schema must be migrated with \"migrate up\"")
+	}
+
 	priceServiceConn, err := grpc.Dial(":8080", grpc.WithInsecure())
 	if err != nil {
 		return
@@ -80,13 +152,120 @@ func main() {
 
 	rps := repository.NewTradingRepository(pool)
 	priceServiceRps := repository.NewPriceServiceClient(priceServiceClient)
-	balanceServiceRps := repository.NewBalanceRepository(balanceServiceClient)
+	go priceServiceRps.Run(context.Background())
+	var balanceServiceRps service.BalanceRepository = repository.NewBalanceRepository(balanceServiceClient, pool)
+	if cfg.LocalBalance > 0 {
+		logrus.Warn("using local in-memory balances instead of balance service")
+		balanceServiceRps = repository.NewLocalBalanceRepository(nil, decimal.NewFromFloat(cfg.LocalBalance))
+	}
 
 	positionManager := model.NewPositionManager()
 
-	srv := service.NewTradingService(rps, priceServiceRps, balanceServiceRps, positionManager)
+	marginRules := &model.MarginRules{
+		MaxLeverage:           decimal.NewFromFloat(cfg.MaxLeverage),
+		MaintenanceMarginRate: decimal.NewFromFloat(cfg.MaintenanceMarginRate),
+		MarginCallLevel:       decimal.NewFromFloat(cfg.MarginCallLevel),
+		LiquidationLevel:      decimal.NewFromFloat(cfg.LiquidationLevel),
+	}
+
+	rounding := &model.RoundingRules{
+		MoneyScale: int32(cfg.MoneyScale),
+		Default:    model.Precision{TickSize: decimal.New(1, -int32(cfg.PriceScale)), AmountScale: int32(cfg.AmountScale)},
+	}
+	instruments := model.NewInstrumentRegistry()
+
+	feeTiers, err := model.ParseFeeTiers(cfg.FeeTiers)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"FeeTiers": cfg.FeeTiers}).Errorf("ParseFeeTiers: %v", err)
+		return
+	}
+	feeOverrides, err := model.ParseFeeOverrides(cfg.FeeOverrides)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"FeeOverrides": cfg.FeeOverrides}).Errorf("ParseFeeOverrides: %v", err)
+		return
+	}
+	commissions := &model.CommissionRules{
+		Default: model.FeeSchedule{
+			Flat:  decimal.NewFromFloat(cfg.FeeFlat),
+			Rate:  decimal.NewFromFloat(cfg.FeeRate),
+			Tiers: feeTiers,
+		},
+		Instruments: feeOverrides,
+	}
+
+	riskLimits := &model.RiskLimits{
+		MaxOpenPositions:      cfg.MaxOpenPositions,
+		MaxInstrumentNotional: decimal.NewFromFloat(cfg.MaxInstrumentNotional),
+		MaxTotalExposure:      decimal.NewFromFloat(cfg.MaxTotalExposure),
+		MaxDailyLoss:          decimal.NewFromFloat(cfg.MaxDailyLoss),
+		MaxOrderSize:          decimal.NewFromFloat(cfg.MaxOrderSize),
+	}
+
+	marketLocation, err := time.LoadLocation(cfg.MarketTimezone)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"MarketTimezone": cfg.MarketTimezone}).Errorf("LoadLocation: %v", err)
+		return
+	}
+	schedule := &model.TradingSchedule{MarketClose: cfg.MarketClose, Location: marketLocation}
+
+	srv := service.NewTradingService(rps, priceServiceRps, balanceServiceRps, positionManager, marginRules, schedule, rounding, instruments, commissions, riskLimits, cfg.IdempotencyTTL)
+
+	err = srv.LoadInstruments(context.Background())
+	if err != nil {
+		logrus.Errorf("LoadInstruments: %v", err)
+		return
+	}
 
-	go srv.CheckForShareClosePrice(context.Background())
+	err = srv.ResumeSagas(context.Background(), 0)
+	if err != nil {
+		logrus.Errorf("ResumeSagas: %v", err)
+		return
+	}
+	go srv.ResumeSagasPeriodically(context.Background(), cfg.SagaResumeInterval)
+
+	report, err := srv.RestorePositions(context.Background())
+	if err != nil {
+		logrus.Errorf("RestorePositions: %v", err)
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"Restored":          len(report.Restored),
+		"OnlyInDatabase":    report.OnlyInDatabase,
+		"OnlyInManager":     report.OnlyInManager,
+		"UnavailableShares": report.UnavailableShares,
+	}).Info("positions restored")
+
+	go srv.CheckForShareClosePrice(context.Background(), cfg.PositionMonitorInterval)
+
+	pendingOrders, err := srv.RestoreOrders(context.Background())
+	if err != nil {
+		logrus.Errorf("RestoreOrders: %v", err)
+		return
+	}
+	logrus.WithFields(logrus.Fields{"PendingOrders": pendingOrders}).Info("orders restored")
+	go srv.MatchOrders(context.Background(), cfg.OrderMatchInterval)
+	go srv.ExpireOrdersAndPositions(context.Background(), cfg.ExpiryCheckInterval)
+
+	var eventSink service.EventSink = repository.DiscardEventSink{}
+	if cfg.EventsFile != "" {
+		var fileSink *repository.FileEventSink
+		fileSink, err = repository.NewFileEventSink(cfg.EventsFile)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"EventsFile": cfg.EventsFile}).Errorf("NewFileEventSink: %v", err)
+			return
+		}
+		defer func() {
+			err = fileSink.Close()
+			if err != nil {
+				fmt.Println("error closing events file")
+			}
+		}()
+		eventSink = fileSink
+	} else {
+		logrus.Warn("EVENTS_FILE is not set, position events are discarded")
+	}
+	relay := service.NewOutboxRelay(rps, eventSink, cfg.OutboxRetention)
+	go relay.Run(context.Background(), cfg.OutboxRelayInterval)
 
 	handler := handlers.NewTradingHandler(srv, validator.New())
 